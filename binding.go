@@ -0,0 +1,414 @@
+package httpcaller
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// binding splits a request struct into path parameters, query parameters and
+// headers using the "path", "query" and "header" struct tags:
+//
+//	type GetOrderRequest struct {
+//		OrderID string `path:"id"`
+//		Page    int    `query:"page,omitempty"`
+//		Tenant  string `header:"X-Tenant"`
+//		Note    string `json:"note"`
+//	}
+//
+// Embedded structs are searched for bound fields unless they have a `json`,
+// `xml` or `form` name tag. Bound fields are left out of the request body, as
+// if tagged "-", unless they also carry an explicit `json`, `xml` or `form`
+// tag. Query and header fields accept an omitempty option that skips zero
+// values; path fields are always required.
+type binding struct {
+	path   []boundField
+	query  []boundField
+	header []boundField
+
+	// bodyStruct projects requests onto the fields encoded into the body. It
+	// is nil when no field is left out.
+	bodyStruct *bodyStruct
+}
+
+type boundField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+// newBinding returns nil when t has no bound fields.
+func newBinding(t reflect.Type) *binding {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	b := &binding{}
+	b.collect(t, nil, make(map[reflect.Type]bool))
+	if len(b.path) == 0 && len(b.query) == 0 && len(b.header) == 0 {
+		return nil
+	}
+	b.collectBody(t)
+	return b
+}
+
+var bodyMarshalerTypes = []reflect.Type{
+	reflect.TypeFor[json.Marshaler](),
+	reflect.TypeFor[xml.Marshaler](),
+	reflect.TypeFor[encoding.TextMarshaler](),
+}
+
+// bodyStruct is a copy of a request struct type without its bound fields.
+// Embedded structs are copied as embedded structs rather than flattened, so
+// the codecs resolve promoted and shadowed fields as they would for the
+// request type.
+type bodyStruct struct {
+	typ    reflect.Type
+	fields []bodyField
+}
+
+// bodyField copies field src of the request to field dst of the body.
+// embedded is set for embedded structs, which are projected in turn.
+type bodyField struct {
+	src      int
+	dst      int
+	embedded *bodyStruct
+}
+
+// collectBody sets the body projection of t when t has bound fields that are
+// left out of the body. Types that encode themselves are left as they are,
+// as are self-embedding types.
+func (b *binding) collectBody(t reflect.Type) {
+	for _, marshaler := range bodyMarshalerTypes {
+		if reflect.PointerTo(t).Implements(marshaler) {
+			return
+		}
+	}
+
+	s, excluded, ok := projectBody(t, true, true, make(map[reflect.Type]bool))
+	if ok && excluded {
+		b.bodyStruct = s
+	}
+}
+
+// projectBody builds the body projection of t, reporting whether any bound
+// field was left out. bind is false within embedded structs that have a name
+// tag, as their fields are not bound. The root projection gets an XMLName
+// field so that XMLCodec keeps the name of t as the root element.
+func projectBody(t reflect.Type, bind bool, root bool, visiting map[reflect.Type]bool) (*bodyStruct, bool, bool) {
+	if visiting[t] {
+		return nil, false, false
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		names[t.Field(i).Name] = true
+	}
+
+	s := &bodyStruct{}
+	var fields []reflect.StructField
+	var excluded bool
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if embedded, ok := embeddedStruct(field); ok {
+			inner, innerExcluded, ok := projectBody(embedded, bind && !hasNameTag(field), false, visiting)
+			if !ok {
+				return nil, false, false
+			}
+			excluded = excluded || innerExcluded
+
+			// The projection has no methods, so reflect.StructOf can embed
+			// it, but embedded fields of unexported types need an exported
+			// name. The codecs ignore the names of untagged embedded fields.
+			if field.Type.Kind() == reflect.Pointer {
+				field.Type = reflect.PointerTo(inner.typ)
+			} else {
+				field.Type = inner.typ
+			}
+			if !field.IsExported() {
+				field.Name = strings.ToUpper(field.Name[:1]) + field.Name[1:]
+				for names[field.Name] {
+					field.Name += "_"
+				}
+				names[field.Name] = true
+				field.PkgPath = ""
+			}
+			s.fields = append(s.fields, bodyField{src: i, dst: len(fields), embedded: inner})
+		} else {
+			if !field.IsExported() {
+				continue
+			}
+			if bind && isBound(field) && !hasBodyTag(field) {
+				excluded = true
+				continue
+			}
+			// Embedded types other than structs encode like named fields.
+			field.Anonymous = false
+			s.fields = append(s.fields, bodyField{src: i, dst: len(fields)})
+		}
+
+		field.Index = nil
+		field.Offset = 0
+		fields = append(fields, field)
+	}
+
+	if _, ok := t.FieldByName("XMLName"); root && !ok && t.Name() != "" {
+		fields = append(fields, reflect.StructField{
+			Name: "XMLName",
+			Type: reflect.TypeFor[xml.Name](),
+			Tag:  reflect.StructTag(fmt.Sprintf(`xml:"%s" json:"-"`, t.Name())),
+		})
+	}
+	s.typ = reflect.StructOf(fields)
+	return s, excluded, true
+}
+
+// copy copies the projected fields of src to dst.
+func (s *bodyStruct) copy(dst, src reflect.Value) {
+	for _, field := range s.fields {
+		sv := src.Field(field.src)
+		dv := dst.Field(field.dst)
+		switch {
+		case field.embedded == nil:
+			dv.Set(sv)
+		case sv.Kind() == reflect.Pointer:
+			if !sv.IsNil() {
+				p := reflect.New(field.embedded.typ)
+				field.embedded.copy(p.Elem(), sv.Elem())
+				dv.Set(p)
+			}
+		default:
+			field.embedded.copy(dv, sv)
+		}
+	}
+}
+
+// embeddedStruct returns the struct type of an embedded struct or struct
+// pointer field.
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+func isBound(field reflect.StructField) bool {
+	for _, tag := range []string{"path", "query", "header"} {
+		if _, ok := field.Tag.Lookup(tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBodyTag(field reflect.StructField) bool {
+	for _, tag := range []string{"json", "xml", "form"} {
+		if _, ok := field.Tag.Lookup(tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNameTag reports whether a `json`, `xml` or `form` tag names the field,
+// which keeps an embedded struct from being flattened.
+func hasNameTag(field reflect.StructField) bool {
+	for _, tag := range []string{"json", "xml", "form"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// body returns the value to encode as the request body: req without its
+// bound fields. It is safe to call on a nil binding.
+func (b *binding) body(req any) any {
+	if b == nil || b.bodyStruct == nil {
+		return req
+	}
+
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return req
+		}
+		v = v.Elem()
+	}
+
+	out := reflect.New(b.bodyStruct.typ).Elem()
+	b.bodyStruct.copy(out, v)
+	return out.Interface()
+}
+
+func (b *binding) collect(t reflect.Type, index []int, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if embedded, ok := embeddedStruct(field); ok && !hasNameTag(field) {
+			b.collect(embedded, fieldIndex, visiting)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("path"); ok {
+			b.path = append(b.path, newBoundField(fieldIndex, tag))
+		}
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			b.query = append(b.query, newBoundField(fieldIndex, tag))
+		}
+		if tag, ok := field.Tag.Lookup("header"); ok {
+			b.header = append(b.header, newBoundField(fieldIndex, tag))
+		}
+	}
+}
+
+func newBoundField(index []int, tag string) boundField {
+	name, options, _ := strings.Cut(tag, ",")
+	return boundField{
+		index:     index,
+		name:      name,
+		omitEmpty: options == "omitempty",
+	}
+}
+
+// apply adds the bound values of req to pathParams, query and headers.
+func (b *binding) apply(req any, pathParams map[string]string, query url.Values, headers map[string]string) error {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	for _, field := range b.path {
+		values, err := fieldValues(v, field)
+		if err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			pathParams[field.name] = values[0]
+		}
+	}
+	for _, field := range b.query {
+		values, err := fieldValues(v, field)
+		if err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			query[field.name] = values
+		}
+	}
+	for _, field := range b.header {
+		values, err := fieldValues(v, field)
+		if err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			headers[field.name] = strings.Join(values, ",")
+		}
+	}
+	return nil
+}
+
+func fieldValues(v reflect.Value, field boundField) ([]string, error) {
+	fv, err := v.FieldByIndexErr(field.index)
+	if err != nil {
+		return nil, nil
+	}
+	if field.omitEmpty && fv.IsZero() {
+		return nil, nil
+	}
+
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		if _, ok := fv.Interface().(encoding.TextMarshaler); !ok && fv.Type().Elem().Kind() != reflect.Uint8 {
+			values := make([]string, 0, fv.Len())
+			for i := 0; i < fv.Len(); i++ {
+				value, ok, err := formatValue(fv.Index(i))
+				if err != nil {
+					return nil, fmt.Errorf("field %q: %w", field.name, err)
+				}
+				if ok {
+					values = append(values, value)
+				}
+			}
+			return values, nil
+		}
+	}
+
+	value, ok, err := formatValue(fv)
+	if err != nil {
+		return nil, fmt.Errorf("field %q: %w", field.name, err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	return []string{value}, nil
+}
+
+// formatValue renders a scalar field. It reports false for nil pointers.
+func formatValue(v reflect.Value) (string, bool, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			return string(text), err == nil, err
+		}
+		v = v.Elem()
+	}
+
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err == nil, err
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), true, nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true, nil
+		}
+	}
+	return "", false, fmt.Errorf("unsupported type %s", v.Type())
+}
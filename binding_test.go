@@ -0,0 +1,316 @@
+package httpcaller
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindingPaging struct {
+	Page  int `query:"page,omitempty" json:"-"`
+	Limit int `query:"limit,omitempty" json:"-"`
+}
+
+type updateOrderRequest struct {
+	bindingPaging
+	OrderID string    `path:"id" json:"-"`
+	Tags    []string  `query:"tag" json:"-"`
+	Since   time.Time `query:"since,omitempty" json:"-"`
+	Tenant  string    `header:"X-Tenant" json:"-"`
+	TraceID *string   `header:"X-Trace-Id,omitempty" json:"-"`
+	Note    string    `json:"note"`
+}
+
+type bindingAudit struct {
+	Name  string
+	Label string
+}
+
+func (a bindingAudit) String() string {
+	return a.Name
+}
+
+type bindingOwner struct {
+	Label string
+}
+
+func TestBinding(t *testing.T) {
+	t.Run("Successful PUT request bound from struct tags", func(t *testing.T) {
+		transport := &recordingTransport{}
+		caller := NewPutCaller[updateOrderRequest, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+			CallerOptions{
+				DefaultHeaders: map[string]string{"X-Tenant": "default"},
+			},
+		)
+
+		req := updateOrderRequest{
+			bindingPaging: bindingPaging{Page: 2},
+			OrderID:       "A/1",
+			Tags:          []string{"x", "y"},
+			Tenant:        "acme",
+			Note:          "rush",
+		}
+
+		ctx := context.Background()
+		res, err := caller.Put(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, "https://example.com/orders/A%2F1?page=2&tag=x&tag=y", transport.request.URL.String())
+		assert.Equal(t, "acme", transport.request.Header.Get("X-Tenant"))
+		assert.Empty(t, transport.request.Header.Get("X-Trace-Id"))
+		assert.JSONEq(t, `{"note": "rush"}`, string(transport.body))
+	})
+
+	t.Run("Successful request resolves shadowed embedded fields like encoding/json", func(t *testing.T) {
+		type request struct {
+			bindingAudit
+			*bindingOwner
+			Name    string
+			OrderID string `path:"id"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		req := request{
+			bindingAudit: bindingAudit{Name: "inner", Label: "audit"},
+			bindingOwner: &bindingOwner{Label: "owner"},
+			Name:         "outer",
+			OrderID:      "1",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/orders/1", transport.request.URL.String())
+		assert.JSONEq(t, `{"Name": "outer"}`, string(transport.body))
+	})
+
+	t.Run("Successful request promotes embedded fields shadowed by bound fields", func(t *testing.T) {
+		type base struct {
+			ID   string
+			Name string `json:"name"`
+		}
+		type request struct {
+			base
+			ID string `path:"id"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, request{base: base{ID: "inner", Name: "n"}, ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/orders/1", transport.request.URL.String())
+		assert.JSONEq(t, `{"ID": "inner", "name": "n"}`, string(transport.body))
+	})
+
+	t.Run("Successful request keeps tagged embedded structs nested", func(t *testing.T) {
+		type inner struct {
+			Name   string `json:"name"`
+			Tenant string `header:"X-Tenant"`
+		}
+		type request struct {
+			inner   `json:"inner"`
+			OrderID string `path:"id"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, request{inner: inner{Name: "n", Tenant: "acme"}, OrderID: "1"})
+		assert.NoError(t, err)
+		assert.Empty(t, transport.request.Header.Get("X-Tenant"))
+		assert.JSONEq(t, `{"inner": {"name": "n", "Tenant": "acme"}}`, string(transport.body))
+	})
+
+	t.Run("Successful XML request keeps the root element name", func(t *testing.T) {
+		type paging struct {
+			Page int `query:"page,omitempty"`
+		}
+		type orderRequest struct {
+			paging
+			OrderID string `path:"id"`
+			Name    string `xml:"name"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[orderRequest, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+			CallerOptions{RequestCodec: XMLCodec},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, orderRequest{paging: paging{Page: 2}, OrderID: "1", Name: "n"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/orders/1?page=2", transport.request.URL.String())
+		assert.Equal(t, "<orderRequest><name>n</name></orderRequest>", string(transport.body))
+	})
+
+	t.Run("Successful XML request keeps the XMLName of the request", func(t *testing.T) {
+		type request struct {
+			XMLName xml.Name `xml:"order"`
+			OrderID string   `path:"id"`
+			Name    string   `xml:"name"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+			CallerOptions{RequestCodec: XMLCodec},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, request{OrderID: "1", Name: "n"})
+		assert.NoError(t, err)
+		assert.Equal(t, "<order><name>n</name></order>", string(transport.body))
+	})
+
+	t.Run("Call options override bound values", func(t *testing.T) {
+		transport := &recordingTransport{}
+		caller := NewPostCaller[*updateOrderRequest, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		traceID := "trace-1"
+		since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		req := &updateOrderRequest{OrderID: "1", Since: since, Tenant: "acme", TraceID: &traceID}
+
+		optional := CallOption{
+			Header:    map[string]string{"X-Tenant": "override"},
+			PathParam: map[string]string{"id": "2"},
+		}
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, req, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/orders/2?since=2024-01-02T03%3A04%3A05Z", transport.request.URL.String())
+		assert.Equal(t, "override", transport.request.Header.Get("X-Tenant"))
+		assert.Equal(t, "trace-1", transport.request.Header.Get("X-Trace-Id"))
+	})
+
+	t.Run("Failed request due to missing bound path parameter", func(t *testing.T) {
+		transport := &recordingTransport{}
+		caller := NewPostCaller[updateOrderRequest, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, updateOrderRequest{})
+		assert.ErrorIs(t, err, ErrPathParam)
+		assert.Nil(t, transport.request)
+	})
+
+	t.Run("Failed request due to unsupported field type", func(t *testing.T) {
+		type request struct {
+			Filter map[string]string `query:"filter"`
+		}
+
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: &recordingTransport{}},
+			"https://example.com",
+			"orders",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, request{Filter: map[string]string{"a": "b"}})
+		assert.ErrorIs(t, err, ErrCreateRequest)
+		assert.Contains(t, err.Error(), `field "filter": unsupported type map[string]string`)
+	})
+
+	t.Run("Successful request leaves bound fields out of the body", func(t *testing.T) {
+		type base struct {
+			Tenant string `header:"X-Tenant"`
+			Region string `json:"region"`
+		}
+		type request struct {
+			*base
+			OrderID string `path:"id"`
+			Token   string `query:"token"`
+			Version int    `header:"X-Version" json:"version"`
+			Note    string `json:"note"`
+			Count   int
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+		)
+
+		req := request{
+			base:    &base{Tenant: "acme", Region: "eu"},
+			OrderID: "1",
+			Token:   "secret",
+			Version: 2,
+			Note:    "rush",
+			Count:   3,
+		}
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/orders/1?token=secret", transport.request.URL.String())
+		assert.Equal(t, "acme", transport.request.Header.Get("X-Tenant"))
+		assert.Equal(t, "2", transport.request.Header.Get("X-Version"))
+		assert.JSONEq(t, `{"region": "eu", "version": 2, "note": "rush", "Count": 3}`, string(transport.body))
+	})
+
+	t.Run("Successful form request leaves bound fields out of the body", func(t *testing.T) {
+		type request struct {
+			OrderID string `path:"id"`
+			Note    string `form:"note"`
+		}
+
+		transport := &recordingTransport{}
+		caller := NewPostCaller[request, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"orders/:id",
+			CallerOptions{RequestCodec: FormCodec},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, request{OrderID: "1", Note: "rush"})
+		assert.NoError(t, err)
+		assert.Equal(t, "note=rush", string(transport.body))
+	})
+
+	t.Run("Ignores types without bound fields", func(t *testing.T) {
+		assert.Nil(t, newBinding(reflect.TypeFor[map[string]interface{}]()))
+		assert.Nil(t, newBinding(reflect.TypeFor[struct {
+			Name string `json:"name"`
+		}]()))
+	})
+}
@@ -0,0 +1,551 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Caller is the request pipeline shared by every method-specific caller.
+// It can also be used directly for methods that have no dedicated caller.
+type Caller[request any, response any] struct {
+	httpClient          *http.Client
+	handler             Handler
+	baseURL             string
+	endpoint            string
+	route               *route
+	binding             *binding
+	defaultHeaders      map[string]string
+	defaultQuery        url.Values
+	baseSuccessResponse map[string]interface{}
+	successRules        []SuccessRule
+	envelope            *Envelope
+	isSuccessStatus     func(statusCode int) bool
+	errorBody           ErrorBodyDecoder
+	requestCodec        Codec
+	responseCodec       Codec
+	responseCodecs      []Codec
+	retryPolicy         *RetryPolicy
+	rateLimitGate       *rateLimitGate
+	circuitBreaker      *CircuitBreaker
+	rateLimiter         *RateLimiter
+	clock               Clock
+	onRequest           func(req *http.Request)
+	onResponse          func(res *http.Response, body []byte)
+	onDecodeError       func(raw []byte, err error)
+	onRetry             func(attempt int, err error)
+	logger              *slog.Logger
+	logRedaction        *logRedaction
+	tracer              trace.Tracer
+	propagator          propagation.TextMapPropagator
+}
+
+func NewCaller[request, response any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *Caller[request, response] {
+	defaultHeaders := make(map[string]string)
+	defaultQuery := make(url.Values)
+	baseSuccessResponse := make(map[string]interface{})
+	var successRules []SuccessRule
+	var envelope *Envelope
+	isSuccessStatus := defaultIsSuccessStatus
+	var errorBody ErrorBodyDecoder
+	requestCodec := JSONCodec
+	responseCodec := JSONCodec
+	var responseCodecs []Codec
+	var retryPolicy *RetryPolicy
+	var gate *rateLimitGate
+	var circuitBreaker *CircuitBreaker
+	var rateLimiter *RateLimiter
+	var clock Clock = realClock{}
+	var middlewares []Middleware
+	var onRequest func(req *http.Request)
+	var onResponse func(res *http.Response, body []byte)
+	var onDecodeError func(raw []byte, err error)
+	var onRetry func(attempt int, err error)
+	var logger *slog.Logger
+	var logRedaction LogRedaction
+	tracerProvider := otel.GetTracerProvider()
+	var propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+	if len(options) > 0 {
+		opt := options[0]
+		if opt.DefaultHeaders != nil {
+			defaultHeaders = opt.DefaultHeaders
+		}
+		if opt.DefaultQuery != nil {
+			defaultQuery = opt.DefaultQuery
+		}
+		if opt.BaseSuccessResponse != nil {
+			baseSuccessResponse = opt.BaseSuccessResponse
+		}
+		if opt.SuccessRules != nil {
+			successRules = opt.SuccessRules
+		}
+		if opt.Envelope != nil {
+			envelope = opt.Envelope
+		}
+		if opt.IsSuccessStatus != nil {
+			isSuccessStatus = opt.IsSuccessStatus
+		}
+		if opt.ErrorBody != nil {
+			errorBody = opt.ErrorBody
+		}
+		if opt.RequestCodec != nil {
+			requestCodec = opt.RequestCodec
+		}
+		if opt.ResponseCodec != nil {
+			responseCodec = opt.ResponseCodec
+		}
+		if opt.ResponseCodecs != nil {
+			responseCodecs = opt.ResponseCodecs
+		}
+		if opt.RetryPolicy != nil {
+			retryPolicy = opt.RetryPolicy
+		}
+		if opt.RateLimitHeaders {
+			gate = &rateLimitGate{}
+		}
+		if opt.CircuitBreaker != nil {
+			circuitBreaker = opt.CircuitBreaker
+		}
+		if opt.RateLimiter != nil {
+			rateLimiter = opt.RateLimiter
+		}
+		if opt.Clock != nil {
+			clock = opt.Clock
+		}
+		if opt.Middlewares != nil {
+			middlewares = opt.Middlewares
+		}
+		if opt.OnRequest != nil {
+			onRequest = opt.OnRequest
+		}
+		if opt.OnResponse != nil {
+			onResponse = opt.OnResponse
+		}
+		if opt.OnDecodeError != nil {
+			onDecodeError = opt.OnDecodeError
+		}
+		if opt.OnRetry != nil {
+			onRetry = opt.OnRetry
+		}
+		if opt.Logger != nil {
+			logger = opt.Logger
+		}
+		if opt.LogRedaction != nil {
+			logRedaction = *opt.LogRedaction
+		}
+		if opt.TracerProvider != nil {
+			tracerProvider = opt.TracerProvider
+		}
+		if opt.Propagator != nil {
+			propagator = opt.Propagator
+		}
+	}
+
+	handler := Chain(middlewares...)(func(req *http.Request) (*http.Response, error) {
+		return httpClient.Do(req)
+	})
+
+	return &Caller[request, response]{
+		httpClient:          httpClient,
+		handler:             handler,
+		baseURL:             baseURL,
+		endpoint:            endpoint,
+		route:               parseRoute(baseURL, endpoint),
+		binding:             newBinding(reflect.TypeFor[request]()),
+		defaultHeaders:      defaultHeaders,
+		defaultQuery:        defaultQuery,
+		baseSuccessResponse: baseSuccessResponse,
+		successRules:        successRules,
+		envelope:            envelope,
+		isSuccessStatus:     isSuccessStatus,
+		errorBody:           errorBody,
+		requestCodec:        requestCodec,
+		responseCodec:       responseCodec,
+		responseCodecs:      responseCodecs,
+		retryPolicy:         retryPolicy,
+		rateLimitGate:       gate,
+		circuitBreaker:      circuitBreaker,
+		rateLimiter:         rateLimiter,
+		clock:               clock,
+		onRequest:           onRequest,
+		onResponse:          onResponse,
+		onDecodeError:       onDecodeError,
+		onRetry:             onRetry,
+		logger:              logger,
+		logRedaction:        newLogRedaction(logRedaction),
+		tracer:              tracerProvider.Tracer(tracerName),
+		propagator:          propagator,
+	}
+}
+
+// Do sends req encoded with the request codec using the given HTTP method.
+func (c *Caller[request, response]) Do(ctx context.Context, method string, req request, optional ...CallOption) (response, error) {
+	return c.do(ctx, method, &req, optional...)
+}
+
+// DoWithMeta is like Do but also returns the response metadata.
+func (c *Caller[request, response]) DoWithMeta(ctx context.Context, method string, req request, optional ...CallOption) (Result[response], error) {
+	return c.doWithMeta(ctx, method, &req, optional...)
+}
+
+// do runs the request pipeline. A nil req sends the request without a body.
+func (c *Caller[request, response]) do(ctx context.Context, method string, req *request, optional ...CallOption) (response, error) {
+	result, err := c.doWithMeta(ctx, method, req, optional...)
+	return result.Body, err
+}
+
+func (c *Caller[request, response]) doWithMeta(ctx context.Context, method string, req *request, optional ...CallOption) (Result[response], error) {
+	var body *requestBody
+	if req != nil {
+		var reqBody bytes.Buffer
+		if err := c.requestCodec.Encode(&reqBody, c.binding.body(*req)); err != nil {
+			return Result[response]{}, fmt.Errorf("%w: %w", ErrEncode, err)
+		}
+		body = &requestBody{contentType: c.requestCodec.ContentType(), data: reqBody.Bytes()}
+	}
+	return c.execute(ctx, method, req, body, optional...)
+}
+
+// execute sends body, which may be nil, and decodes the response. req is only
+// used for struct-tag binding.
+func (c *Caller[request, response]) execute(ctx context.Context, method string, req *request, body *requestBody, optional ...CallOption) (res Result[response], err error) {
+	start := c.clock.Now()
+	defer func() {
+		res.Duration = c.clock.Now().Sub(start)
+	}()
+
+	requestURL, headers, err := c.prepare(req, optional...)
+	if err != nil {
+		return res, err
+	}
+
+	ctx, span := c.startSpan(ctx, method, requestURL)
+	defer func() {
+		endSpan(span, res.StatusCode, res.Attempts, err)
+	}()
+
+	exchange, attempts, err := c.send(ctx, method, requestURL, headers, body, &res.Body)
+	res.Attempts = attempts
+	if exchange != nil {
+		res.StatusCode = exchange.statusCode
+		res.Header = exchange.header
+		res.URL = exchange.url
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if exchange.decoded {
+		return res, nil
+	}
+
+	if err := c.decode(exchange, &res.Body); err != nil {
+		if c.onDecodeError != nil && errors.Is(err, ErrDecode) {
+			c.onDecodeError(exchange.body, err)
+		}
+		return res, err
+	}
+
+	return res, nil
+}
+
+// decode decodes a buffered response body into res and runs the success
+// checks against it.
+func (c *Caller[request, response]) decode(exchange *exchange, res *response) error {
+	codec := responseCodec(exchange.header.Get("Content-Type"), c.responseCodec, c.responseCodecs)
+	if c.envelope != nil {
+		if err := c.envelope.unwrap(codec, exchange.body, res); err != nil {
+			return err
+		}
+	} else if err := codec.Decode(bytes.NewReader(exchange.body), res); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	if len(c.baseSuccessResponse) > 0 {
+		if err := matchBaseSuccessResponse(codec, exchange.body, c.baseSuccessResponse); err != nil {
+			return err
+		}
+	}
+	if len(c.successRules) > 0 {
+		if err := matchSuccessRules(codec, exchange.body, c.successRules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buffersBody reports whether successful bodies must be read into memory
+// before decoding because a success check or hook needs the raw bytes.
+func (c *Caller[request, response]) buffersBody() bool {
+	return len(c.baseSuccessResponse) > 0 || len(c.successRules) > 0 || c.envelope != nil ||
+		c.onResponse != nil || c.onDecodeError != nil ||
+		(c.logger != nil && c.logger.Enabled(context.Background(), slog.LevelDebug))
+}
+
+// prepare builds the request URL and headers from the caller defaults, the
+// bound fields of req and the call options, in increasing precedence.
+func (c *Caller[request, response]) prepare(req *request, optional ...CallOption) (string, map[string]string, error) {
+	headers := make(map[string]string)
+	for key, value := range c.defaultHeaders {
+		headers[key] = value
+	}
+
+	query := make(url.Values)
+	for key, values := range c.defaultQuery {
+		query[key] = append([]string(nil), values...)
+	}
+
+	pathParams := make(map[string]string)
+	if req != nil && c.binding != nil {
+		if err := c.binding.apply(*req, pathParams, query, headers); err != nil {
+			return "", nil, fmt.Errorf("%w: bind request: %w", ErrCreateRequest, err)
+		}
+	}
+
+	if len(optional) > 0 {
+		opt := optional[0]
+		if opt.Header != nil {
+			for key, value := range opt.Header {
+				headers[key] = value
+			}
+		}
+		for key, value := range opt.PathParam {
+			pathParams[key] = value
+		}
+		for key, values := range opt.Query {
+			query[key] = append([]string(nil), values...)
+		}
+	}
+
+	requestURL, err := c.route.expand(pathParams)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
+	}
+
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(requestURL, "?") {
+			separator = "&"
+		}
+		requestURL += separator + query.Encode()
+	}
+
+	return requestURL, headers, nil
+}
+
+// requestBody is either a buffered body that is resent on every attempt or a
+// streamed body that can only be sent once.
+type requestBody struct {
+	contentType string
+	data        []byte
+	open        func() io.ReadCloser
+}
+
+// exchange is the outcome of a single attempt that received a response. The
+// body is either buffered or, when decoded is set, already decoded straight
+// from the response stream.
+type exchange struct {
+	statusCode int
+	header     http.Header
+	url        string
+	body       []byte
+	decoded    bool
+
+	// received is the number of body bytes read, or -1 when the body is
+	// left to the caller.
+	received int64
+}
+
+// send performs the request, retrying according to the retry policy. It
+// returns the last response received, if any, and the number of attempts
+// made.
+func (c *Caller[request, response]) send(ctx context.Context, method string, requestURL string, headers map[string]string, body *requestBody, res *response) (*exchange, int, error) {
+	maxAttempts := c.retryPolicy.maxAttempts()
+	if body != nil && body.open != nil {
+		maxAttempts = 1
+	}
+	for attempt := 1; ; attempt++ {
+		if c.rateLimitGate != nil {
+			if err := c.rateLimitGate.wait(ctx, c.clock); err != nil {
+				return nil, attempt - 1, fmt.Errorf("rate limit wait aborted: %w", err)
+			}
+		}
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, attempt - 1, fmt.Errorf("rate limiter wait aborted: %w", err)
+			}
+		}
+
+		var ex *exchange
+		var err error
+		attemptStart := c.clock.Now()
+		if c.circuitBreaker == nil {
+			ex, err = c.attempt(ctx, method, requestURL, headers, body, res)
+		} else if err = c.circuitBreaker.allow(); err == nil {
+			ex, err = c.attempt(ctx, method, requestURL, headers, body, res)
+			c.circuitBreaker.record(err)
+		}
+		if c.logger != nil {
+			header := streamHeaders(headers)
+			if body != nil {
+				header.Set("Content-Type", body.contentType)
+			}
+			c.logAttempt(ctx, method, attempt, c.clock.Now().Sub(attemptStart), header, body, ex, err)
+		}
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil || !c.retryPolicy.shouldRetry(err) {
+			return ex, attempt, err
+		}
+		if c.onRetry != nil {
+			c.onRetry(attempt, err)
+		}
+
+		select {
+		case <-c.clock.After(c.retryPolicy.retryDelay(attempt, err, c.clock.Now())):
+		case <-ctx.Done():
+			return ex, attempt, fmt.Errorf("retry aborted: %w: %w", ctx.Err(), err)
+		}
+	}
+}
+
+// attempt sends a single request and reads its body. The body is read before
+// returning so that a per-attempt timeout also covers the download. When no
+// success checks need the raw bytes, a successful body is decoded into res
+// as it streams in instead of being buffered. The exchange is returned
+// whenever a response was received, including for unsuccessful statuses.
+func (c *Caller[request, response]) attempt(ctx context.Context, method string, requestURL string, headers map[string]string, body *requestBody, res *response) (*exchange, error) {
+	if timeout := c.retryPolicy.perAttemptTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var bodyReader io.Reader
+	if body != nil && body.open != nil {
+		stream := body.open()
+		defer stream.Close()
+		bodyReader = stream
+	} else if body != nil {
+		bodyReader = bytes.NewReader(body.data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
+	}
+
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", body.contentType)
+	}
+	c.propagator.Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+	if c.onRequest != nil {
+		c.onRequest(httpReq)
+	}
+
+	serverResponse, err := c.handler(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w: %w", strings.ToLower(method), ErrTransport, err)
+	}
+	defer serverResponse.Body.Close()
+
+	if c.rateLimitGate != nil {
+		c.rateLimitGate.observe(serverResponse.Header, c.clock.Now(), c.retryPolicy.maxRetryAfter())
+	}
+
+	ex := &exchange{
+		statusCode: serverResponse.StatusCode,
+		header:     serverResponse.Header,
+		url:        requestURL,
+	}
+	if serverResponse.Request != nil && serverResponse.Request.URL != nil {
+		ex.url = serverResponse.Request.URL.String()
+	}
+
+	if c.isSuccessStatus(serverResponse.StatusCode) && !c.buffersBody() {
+		var zero response
+		*res = zero
+
+		codec := responseCodec(serverResponse.Header.Get("Content-Type"), c.responseCodec, c.responseCodecs)
+		reader := &readErrorRecorder{r: serverResponse.Body}
+		err := codec.Decode(reader, res)
+		ex.received = reader.n
+		if err != nil {
+			if reader.err != nil {
+				return ex, fmt.Errorf("%w: %w", ErrReadBody, reader.err)
+			}
+			return ex, fmt.Errorf("%w: %w", ErrDecode, err)
+		}
+		ex.decoded = true
+		return ex, nil
+	}
+
+	bytesResponse, err := io.ReadAll(serverResponse.Body)
+	if err != nil {
+		return ex, fmt.Errorf("%w: %w", ErrReadBody, err)
+	}
+	ex.body = bytesResponse
+	ex.received = int64(len(bytesResponse))
+
+	if c.onResponse != nil {
+		c.onResponse(serverResponse, bytesResponse)
+	}
+
+	if !c.isSuccessStatus(serverResponse.StatusCode) {
+		return ex, c.newHTTPError(method, requestURL, serverResponse, bytesResponse)
+	}
+
+	return ex, nil
+}
+
+func (c *Caller[request, response]) newHTTPError(method string, requestURL string, serverResponse *http.Response, body []byte) *HTTPError {
+	httpErr := &HTTPError{
+		Method:     method,
+		URL:        requestURL,
+		StatusCode: serverResponse.StatusCode,
+		Header:     serverResponse.Header,
+		Body:       body,
+	}
+	if c.errorBody != nil {
+		codec := responseCodec(serverResponse.Header.Get("Content-Type"), c.responseCodec, c.responseCodecs)
+		if errBody, err := c.errorBody(codec, body); err == nil {
+			httpErr.ErrorBody = errBody
+		}
+	}
+	return httpErr
+}
+
+// readErrorRecorder remembers the first read error other than io.EOF so that
+// failures of the connection can be told apart from malformed bodies.
+type readErrorRecorder struct {
+	r   io.Reader
+	err error
+	n   int64
+}
+
+func (r *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
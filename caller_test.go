@@ -0,0 +1,156 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaller(t *testing.T) {
+	t.Run("Successful request with custom method", func(t *testing.T) {
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		caller := NewCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test/:id",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		optional := CallOption{
+			PathParam: map[string]string{
+				"id": "123",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Do(ctx, "OPTIONS", req, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, "OPTIONS", transport.request.Method)
+		assert.Equal(t, "https://example.com/test/123", transport.request.URL.String())
+		assert.Equal(t, "application/json", transport.request.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"test": "data"}`, string(transport.body))
+	})
+
+	t.Run("Successful request without body", func(t *testing.T) {
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodGet, transport.request.Method)
+		assert.Empty(t, transport.request.Header.Get("Content-Type"))
+		assert.Empty(t, transport.body)
+	})
+
+	t.Run("Successful request with merged query parameters", func(t *testing.T) {
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultQuery: url.Values{
+					"sort":  {"asc"},
+					"limit": {"10"},
+				},
+			},
+		)
+
+		optional := CallOption{
+			Query: url.Values{
+				"sort": {"desc"},
+				"tag":  {"a&b", "c d"},
+			},
+		}
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "limit=10&sort=desc&tag=a%26b&tag=c+d", transport.request.URL.RawQuery)
+		assert.Equal(t, []string{"a&b", "c d"}, transport.request.URL.Query()["tag"])
+	})
+
+	t.Run("Successful request keeps query already in endpoint", func(t *testing.T) {
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test?version=2",
+		)
+
+		optional := CallOption{
+			Query: url.Values{
+				"page": {"2"},
+			},
+		}
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "version=2&page=2", transport.request.URL.RawQuery)
+	})
+
+	t.Run("Default query is not modified by call options", func(t *testing.T) {
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		defaultQuery := url.Values{"sort": {"asc"}}
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{DefaultQuery: defaultQuery},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx, CallOption{Query: url.Values{"sort": {"desc"}}})
+		assert.NoError(t, err)
+		assert.Equal(t, url.Values{"sort": {"asc"}}, defaultQuery)
+	})
+}
+
+// Recording transport that keeps the last request for assertions
+type recordingTransport struct {
+	request *http.Request
+	body    []byte
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.request = req
+	r.body = nil
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.body = body
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"test": "data"}`)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConsecutiveFailures = 5
+	defaultFailureWindowSize   = 20
+	defaultCircuitCoolDown     = 30 * time.Second
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request with ErrCircuitOpen until the
+	// cool-down period has passed.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to
+	// decide whether to close or re-open the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerSettings configures a CircuitBreaker. When neither
+// ConsecutiveFailures nor FailureRateThreshold is set, the circuit opens
+// after 5 consecutive failures.
+type CircuitBreakerSettings struct {
+	// ConsecutiveFailures opens the circuit after this many failures in a
+	// row.
+	ConsecutiveFailures int
+
+	// FailureRateThreshold opens the circuit when the share of failures
+	// among the last WindowSize requests reaches it (0 < rate <= 1). The
+	// rate is only evaluated once MinRequests requests have been recorded.
+	FailureRateThreshold float64
+	WindowSize           int
+	MinRequests          int
+
+	// CoolDown is how long the circuit stays open before probing. Defaults
+	// to 30 seconds.
+	CoolDown time.Duration
+
+	// HalfOpenProbes is the number of probe requests let through while half
+	// open. The circuit closes once all of them succeed. Defaults to 1.
+	HalfOpenProbes int
+
+	// IsFailure reports whether a request error counts as a failure.
+	// Defaults to transport errors, read errors and 5xx responses.
+	IsFailure func(err error) bool
+
+	// OnStateChange is called after every state transition.
+	OnStateChange func(from, to CircuitState)
+
+	// Clock defaults to the system clock.
+	Clock Clock
+}
+
+// CircuitBreaker fails requests fast while a dependency is unhealthy. Share
+// one breaker between callers to break per host instead of per caller.
+type CircuitBreaker struct {
+	settings CircuitBreakerSettings
+
+	mu             sync.Mutex
+	state          CircuitState
+	openedAt       time.Time
+	consecutive    int
+	window         []bool
+	windowNext     int
+	windowFailures int
+	probes         int
+	probeSuccesses int
+}
+
+func NewCircuitBreaker(settings CircuitBreakerSettings) *CircuitBreaker {
+	if settings.ConsecutiveFailures <= 0 && settings.FailureRateThreshold <= 0 {
+		settings.ConsecutiveFailures = defaultConsecutiveFailures
+	}
+	if settings.WindowSize <= 0 {
+		settings.WindowSize = defaultFailureWindowSize
+	}
+	if settings.MinRequests <= 0 || settings.MinRequests > settings.WindowSize {
+		settings.MinRequests = settings.WindowSize
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaultCircuitCoolDown
+	}
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = defaultIsCircuitFailure
+	}
+	if settings.Clock == nil {
+		settings.Clock = realClock{}
+	}
+
+	return &CircuitBreaker{
+		settings: settings,
+		window:   make([]bool, 0, settings.WindowSize),
+	}
+}
+
+// State returns the current state, moving an open circuit to half open once
+// its cool-down has passed.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	from := b.state
+	to := b.refreshLocked()
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return to
+}
+
+// allow reports whether a request may be sent.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	from := b.state
+	to := b.refreshLocked()
+
+	var err error
+	switch to {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes >= b.settings.HalfOpenProbes {
+			err = ErrCircuitOpen
+		} else {
+			b.probes++
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return err
+}
+
+// record updates the breaker with the outcome of a request let through by
+// allow.
+func (b *CircuitBreaker) record(err error) {
+	failed := err != nil && b.settings.IsFailure(err)
+
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case CircuitClosed:
+		b.recordClosedLocked(failed)
+	case CircuitHalfOpen:
+		if failed {
+			b.setStateLocked(CircuitOpen)
+		} else {
+			b.probeSuccesses++
+			if b.probeSuccesses >= b.settings.HalfOpenProbes {
+				b.setStateLocked(CircuitClosed)
+			}
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+func (b *CircuitBreaker) recordClosedLocked(failed bool) {
+	if failed {
+		b.consecutive++
+	} else {
+		b.consecutive = 0
+	}
+
+	if len(b.window) < b.settings.WindowSize {
+		b.window = append(b.window, failed)
+	} else {
+		if b.window[b.windowNext] {
+			b.windowFailures--
+		}
+		b.window[b.windowNext] = failed
+		b.windowNext = (b.windowNext + 1) % b.settings.WindowSize
+	}
+	if failed {
+		b.windowFailures++
+	}
+
+	if b.settings.ConsecutiveFailures > 0 && b.consecutive >= b.settings.ConsecutiveFailures {
+		b.setStateLocked(CircuitOpen)
+		return
+	}
+	if b.settings.FailureRateThreshold > 0 && len(b.window) >= b.settings.MinRequests {
+		if float64(b.windowFailures)/float64(len(b.window)) >= b.settings.FailureRateThreshold {
+			b.setStateLocked(CircuitOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) refreshLocked() CircuitState {
+	if b.state == CircuitOpen && b.settings.Clock.Now().Sub(b.openedAt) >= b.settings.CoolDown {
+		b.setStateLocked(CircuitHalfOpen)
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) setStateLocked(state CircuitState) {
+	b.state = state
+	b.consecutive = 0
+	b.window = b.window[:0]
+	b.windowNext = 0
+	b.windowFailures = 0
+	b.probes = 0
+	b.probeSuccesses = 0
+	if state == CircuitOpen {
+		b.openedAt = b.settings.Clock.Now()
+	}
+}
+
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, to)
+	}
+}
+
+func defaultIsCircuitFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	return errors.Is(err, ErrTransport) || errors.Is(err, ErrReadBody)
+}
@@ -0,0 +1,162 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("Opens after consecutive failures and fails fast", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{500, 500, 500, 200}}
+		var transitions []string
+
+		breaker := NewCircuitBreaker(CircuitBreakerSettings{
+			ConsecutiveFailures: 2,
+			OnStateChange: func(from, to CircuitState) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			},
+			Clock: newFakeClock(),
+		})
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{CircuitBreaker: breaker},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		_, err = caller.Get(ctx)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		_, err = caller.Get(ctx)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 2, transport.attempts())
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.Equal(t, []string{"closed->open"}, transitions)
+	})
+
+	t.Run("Closes again after successful half-open probes", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{500, 200, 200}}
+		clock := newFakeClock()
+		var transitions []string
+
+		breaker := NewCircuitBreaker(CircuitBreakerSettings{
+			ConsecutiveFailures: 1,
+			CoolDown:            10 * time.Second,
+			HalfOpenProbes:      2,
+			OnStateChange: func(from, to CircuitState) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			},
+			Clock: clock,
+		})
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{CircuitBreaker: breaker},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, CircuitOpen, breaker.State())
+
+		clock.After(10 * time.Second)
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+
+		_, err = caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+
+		_, err = caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, transitions)
+	})
+
+	t.Run("Re-opens when a half-open probe fails", func(t *testing.T) {
+		clock := newFakeClock()
+		breaker := NewCircuitBreaker(CircuitBreakerSettings{
+			ConsecutiveFailures: 1,
+			CoolDown:            time.Second,
+			Clock:               clock,
+		})
+
+		failure := &HTTPError{StatusCode: http.StatusBadGateway}
+
+		assert.NoError(t, breaker.allow())
+		breaker.record(failure)
+		assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+
+		clock.After(time.Second)
+		assert.NoError(t, breaker.allow())
+		assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+		breaker.record(failure)
+		assert.Equal(t, CircuitOpen, breaker.State())
+	})
+
+	t.Run("Opens when the failure rate reaches the threshold", func(t *testing.T) {
+		breaker := NewCircuitBreaker(CircuitBreakerSettings{
+			FailureRateThreshold: 0.5,
+			WindowSize:           4,
+			MinRequests:          4,
+			Clock:                newFakeClock(),
+		})
+
+		transportErr := ErrTransport
+		breaker.record(nil)
+		breaker.record(transportErr)
+		breaker.record(nil)
+		assert.Equal(t, CircuitClosed, breaker.State())
+
+		breaker.record(transportErr)
+		assert.Equal(t, CircuitOpen, breaker.State())
+	})
+
+	t.Run("Ignores client errors and cancellations by default", func(t *testing.T) {
+		breaker := NewCircuitBreaker(CircuitBreakerSettings{
+			ConsecutiveFailures: 1,
+			Clock:               newFakeClock(),
+		})
+
+		breaker.record(&HTTPError{StatusCode: http.StatusNotFound})
+		breaker.record(errors.Join(ErrTransport, context.Canceled))
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("Does not retry while the circuit is open", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{503, 503, 503}}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts: 3,
+					RetryableError: func(err error) bool {
+						return true
+					},
+				},
+				CircuitBreaker: NewCircuitBreaker(CircuitBreakerSettings{
+					ConsecutiveFailures: 1,
+					Clock:               newFakeClock(),
+				}),
+				Clock: newFakeClock(),
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 1, transport.attempts())
+	})
+}
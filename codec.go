@@ -0,0 +1,319 @@
+package httpcaller
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Codec encodes request bodies and decodes response bodies for one media
+// type.
+type Codec interface {
+	// ContentType is sent as the Content-Type of encoded request bodies and
+	// used to pick a response decoder.
+	ContentType() string
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+}
+
+var (
+	// JSONCodec encodes and decodes application/json bodies.
+	JSONCodec Codec = jsonCodec{}
+
+	// XMLCodec encodes and decodes application/xml bodies.
+	XMLCodec Codec = xmlCodec{}
+
+	// FormCodec encodes and decodes application/x-www-form-urlencoded
+	// bodies. It accepts url.Values, map[string]string, map[string][]string
+	// and structs whose fields are tagged with `form:"name"`.
+	FormCodec Codec = formCodec{}
+)
+
+var builtinCodecs = []Codec{JSONCodec, XMLCodec, FormCodec}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Encode(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Decode streams r through a json.Decoder. Like json.Unmarshal, it rejects
+// data after the top-level value.
+func (jsonCodec) Decode(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			return errors.New("invalid character after top-level value")
+		}
+		return err
+	}
+	return nil
+}
+
+type xmlCodec struct{}
+
+func (xmlCodec) ContentType() string {
+	return "application/xml"
+}
+
+func (xmlCodec) Encode(w io.Writer, v any) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+func (xmlCodec) Decode(r io.Reader, v any) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+type formCodec struct{}
+
+func (formCodec) ContentType() string {
+	return "application/x-www-form-urlencoded"
+}
+
+func (formCodec) Encode(w io.Writer, v any) error {
+	values, err := formValues(v)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, values.Encode())
+	return err
+}
+
+func (formCodec) Decode(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	switch target := v.(type) {
+	case *url.Values:
+		*target = values
+	case *map[string][]string:
+		*target = values
+	case *map[string]string:
+		*target = make(map[string]string, len(values))
+		for key := range values {
+			(*target)[key] = values.Get(key)
+		}
+	case *map[string]interface{}:
+		*target = make(map[string]interface{}, len(values))
+		for key := range values {
+			(*target)[key] = values.Get(key)
+		}
+	default:
+		return decodeFormStruct(values, v)
+	}
+	return nil
+}
+
+// formValues converts a form body value into url.Values.
+func formValues(v any) (url.Values, error) {
+	switch value := v.(type) {
+	case url.Values:
+		return value, nil
+	case map[string][]string:
+		return value, nil
+	case map[string]string:
+		values := make(url.Values, len(value))
+		for key, item := range value {
+			values.Set(key, item)
+		}
+		return values, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form codec: unsupported type %T", v)
+	}
+
+	values := make(url.Values)
+	for _, field := range formFields(rv.Type()) {
+		items, err := fieldValues(rv, field)
+		if err != nil {
+			return nil, fmt.Errorf("form codec: %w", err)
+		}
+		if len(items) > 0 {
+			values[field.name] = items
+		}
+	}
+	return values, nil
+}
+
+func formFields(t reflect.Type) []boundField {
+	var fields []boundField
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldIndex := append(append([]int(nil), index...), i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				collect(field.Type, fieldIndex)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			tag, ok := field.Tag.Lookup("form")
+			if !ok || tag == "-" {
+				continue
+			}
+			fields = append(fields, newBoundField(fieldIndex, tag))
+		}
+	}
+	collect(t, nil)
+	return fields
+}
+
+func decodeFormStruct(values url.Values, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form codec: unsupported type %T", v)
+	}
+	rv = rv.Elem()
+
+	for _, field := range formFields(rv.Type()) {
+		items, ok := values[field.name]
+		if !ok || len(items) == 0 {
+			continue
+		}
+		fv := rv.FieldByIndex(field.index)
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+			slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := parseValue(slice.Index(i), item); err != nil {
+					return fmt.Errorf("form codec: field %q: %w", field.name, err)
+				}
+			}
+			fv.Set(slice)
+			continue
+		}
+		if err := parseValue(fv, items[0]); err != nil {
+			return fmt.Errorf("form codec: field %q: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// parseValue is the inverse of formatValue.
+func parseValue(v reflect.Value, text string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return parseValue(v.Elem(), text)
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		v.SetBytes([]byte(text))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// responseCodec picks the codec for a response with the given Content-Type.
+// The configured response codec wins when it matches or when no other codec
+// does.
+func responseCodec(contentType string, fallback Codec, codecs []Codec) Codec {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fallback
+	}
+	if mediaTypeMatches(fallback.ContentType(), mediaType) {
+		return fallback
+	}
+	for _, codec := range codecs {
+		if mediaTypeMatches(codec.ContentType(), mediaType) {
+			return codec
+		}
+	}
+	for _, codec := range builtinCodecs {
+		if mediaTypeMatches(codec.ContentType(), mediaType) {
+			return codec
+		}
+	}
+	return fallback
+}
+
+// mediaTypeMatches reports whether a response media type can be decoded by a
+// codec for codecType. Structured syntax suffixes such as +json and +xml, and
+// text/xml, are accepted by the JSON and XML codecs.
+func mediaTypeMatches(codecType string, mediaType string) bool {
+	codecMediaType, _, err := mime.ParseMediaType(codecType)
+	if err != nil {
+		return false
+	}
+	if codecMediaType == mediaType {
+		return true
+	}
+	switch codecMediaType {
+	case "application/json":
+		return strings.HasSuffix(mediaType, "+json")
+	case "application/xml":
+		return mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+	}
+	return false
+}
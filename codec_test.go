@@ -0,0 +1,176 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type formLoginRequest struct {
+	Username string   `form:"username"`
+	Remember bool     `form:"remember,omitempty"`
+	Scopes   []string `form:"scope"`
+	Ignored  string
+}
+
+type xmlAccount struct {
+	XMLName xml.Name `xml:"account"`
+	ID      int      `xml:"id"`
+	Status  string   `xml:"status"`
+}
+
+type upperCodec struct{}
+
+func (upperCodec) ContentType() string {
+	return "text/x-upper"
+}
+
+func (upperCodec) Encode(w io.Writer, v any) error {
+	_, err := io.WriteString(w, strings.ToUpper(v.(string)))
+	return err
+}
+
+func (upperCodec) Decode(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	*(v.(*string)) = strings.ToLower(string(data))
+	return nil
+}
+
+func TestCodec(t *testing.T) {
+	t.Run("Successful POST request with form-urlencoded body", func(t *testing.T) {
+		transport := &recordingTransport{}
+		caller := NewPostCaller[formLoginRequest, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"login",
+			CallerOptions{RequestCodec: FormCodec},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, formLoginRequest{Username: "jane doe", Scopes: []string{"read", "write"}, Ignored: "x"})
+		assert.NoError(t, err)
+		assert.Equal(t, "application/x-www-form-urlencoded", transport.request.Header.Get("Content-Type"))
+		assert.Equal(t, "scope=read&scope=write&username=jane+doe", string(transport.body))
+	})
+
+	t.Run("Successful GET request decoding XML by response content type", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"text/xml; charset=utf-8"}},
+					Body:       io.NopCloser(bytes.NewBufferString(`<account><id>7</id><status>active</status></account>`)),
+				}, nil
+			}),
+		}
+
+		caller := NewGetCaller[xmlAccount](mockClient, "https://example.com", "account")
+
+		ctx := context.Background()
+		res, err := caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, res.ID)
+		assert.Equal(t, "active", res.Status)
+	})
+
+	t.Run("Successful GET request with custom codec and form success check", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+					Body:       io.NopCloser(bytes.NewBufferString(`status=ok&id=9`)),
+				}, nil
+			}),
+		}
+
+		caller := NewGetCaller[url.Values](
+			mockClient,
+			"https://example.com",
+			"legacy",
+			CallerOptions{
+				ResponseCodecs: []Codec{upperCodec{}},
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "ok",
+				},
+			},
+		)
+
+		ctx := context.Background()
+		res, err := caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "9", res.Get("id"))
+	})
+
+	t.Run("Successful POST request with custom request and response codec", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {req.Header.Get("Content-Type")}},
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			}),
+		}
+
+		caller := NewPostCaller[string, string](
+			mockClient,
+			"https://example.com",
+			"echo",
+			CallerOptions{
+				RequestCodec:   upperCodec{},
+				ResponseCodecs: []Codec{upperCodec{}},
+			},
+		)
+
+		ctx := context.Background()
+		res, err := caller.Post(ctx, "Hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", res)
+	})
+
+	t.Run("Form codec round-trips structs", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, FormCodec.Encode(&buf, &formLoginRequest{Username: "jane", Remember: true}))
+		assert.Equal(t, "remember=true&username=jane", buf.String())
+
+		var decoded formLoginRequest
+		assert.NoError(t, FormCodec.Decode(strings.NewReader("username=jane&remember=true&scope=a&scope=b"), &decoded))
+		assert.Equal(t, formLoginRequest{Username: "jane", Remember: true, Scopes: []string{"a", "b"}}, decoded)
+
+		assert.Error(t, FormCodec.Encode(&buf, 42))
+		assert.Error(t, FormCodec.Decode(strings.NewReader("remember=maybe"), &decoded))
+	})
+
+	t.Run("Picks response codec by media type", func(t *testing.T) {
+		custom := upperCodec{}
+		tests := []struct {
+			contentType string
+			expected    Codec
+		}{
+			{contentType: "application/json", expected: JSONCodec},
+			{contentType: "application/problem+json", expected: JSONCodec},
+			{contentType: "application/xml", expected: XMLCodec},
+			{contentType: "application/atom+xml", expected: XMLCodec},
+			{contentType: "application/x-www-form-urlencoded", expected: FormCodec},
+			{contentType: "text/x-upper", expected: custom},
+			{contentType: "text/html", expected: JSONCodec},
+			{contentType: "", expected: JSONCodec},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.expected, responseCodec(tt.contentType, JSONCodec, []Codec{custom}), tt.contentType)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+)
+
+type DeleteCaller[request any, response any] struct {
+	caller *Caller[request, response]
+}
+
+func NewDeleteCaller[request, response any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *DeleteCaller[request, response] {
+	return &DeleteCaller[request, response]{
+		caller: NewCaller[request, response](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+// Delete sends a DELETE request without a body.
+func (h *DeleteCaller[request, response]) Delete(ctx context.Context, optional ...CallOption) (response, error) {
+	return h.caller.do(ctx, http.MethodDelete, nil, optional...)
+}
+
+// DeleteWithBody sends a DELETE request whose body is req encoded with the
+// request codec.
+func (h *DeleteCaller[request, response]) DeleteWithBody(ctx context.Context, req request, optional ...CallOption) (response, error) {
+	return h.caller.do(ctx, http.MethodDelete, &req, optional...)
+}
+
+// DeleteWithMeta is like Delete but also returns the response metadata.
+func (h *DeleteCaller[request, response]) DeleteWithMeta(ctx context.Context, optional ...CallOption) (Result[response], error) {
+	return h.caller.doWithMeta(ctx, http.MethodDelete, nil, optional...)
+}
+
+// DeleteWithBodyWithMeta is like DeleteWithBody but also returns the response
+// metadata.
+func (h *DeleteCaller[request, response]) DeleteWithBodyWithMeta(ctx context.Context, req request, optional ...CallOption) (Result[response], error) {
+	return h.caller.doWithMeta(ctx, http.MethodDelete, &req, optional...)
+}
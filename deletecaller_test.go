@@ -0,0 +1,249 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCaller(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockTransport{},
+	}
+
+	t.Run("Successful DELETE request with default headers", func(t *testing.T) {
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		ctx := context.Background()
+		res, err := caller.Delete(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful DELETE request with additional headers", func(t *testing.T) {
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		optional := CallOption{
+			Header: map[string]string{
+				"Custom-Header": "custom_value",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Delete(ctx, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful DELETE request with path parameters", func(t *testing.T) {
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test/:id",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		optional := CallOption{
+			PathParam: map[string]string{
+				"id": "123",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Delete(ctx, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful DELETE request with base success response validation", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data", "status": "success"}`,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "success",
+				},
+			},
+		)
+
+		ctx := context.Background()
+		res, err := caller.Delete(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Failed DELETE request due to unmatched base success response", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data", "status": "error"}`,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "success",
+				},
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Delete(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsuccessful response for key status")
+	})
+
+	t.Run("Failed DELETE request due to network error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				networkError: true,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Delete(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "delete request error")
+	})
+
+	t.Run("Failed DELETE request due to HTTP request creation error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				requestCreationError: true,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Delete(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "create request error")
+	})
+
+	t.Run("Failed DELETE request due to response read error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				readError: true,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Delete(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "read response error")
+	})
+
+	t.Run("Failed DELETE request due to response unmarshal error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				unmarshalError: true,
+			},
+		}
+
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Delete(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal response error")
+	})
+
+	t.Run("Successful DELETE request with body", func(t *testing.T) {
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test/:id",
+		)
+
+		req := map[string]interface{}{
+			"reason": "duplicate",
+		}
+
+		optional := CallOption{
+			PathParam: map[string]string{
+				"id": "123",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.DeleteWithBody(ctx, req, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Failed DELETE request due to JSON marshal error", func(t *testing.T) {
+		caller := NewDeleteCaller[chan int, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := make(chan int)
+
+		ctx := context.Background()
+		_, err := caller.DeleteWithBody(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "marshal request error")
+	})
+}
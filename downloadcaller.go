@@ -0,0 +1,346 @@
+package httpcaller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DownloadRequest configures a single download.
+type DownloadRequest struct {
+	// ExpectedSHA256 is the hex SHA-256 digest the content must match.
+	ExpectedSHA256 string
+
+	// ChecksumHeader names a response header carrying the SHA-256 digest of
+	// the content, hex or base64 encoded. It is used when ExpectedSHA256 is
+	// empty.
+	ChecksumHeader string
+
+	// Progress is called with the number of content bytes written so far and
+	// the total size, or -1 when the size is unknown.
+	Progress func(written, total int64)
+
+	// MaxResumes is how many times an interrupted download is resumed with a
+	// Range request. Resuming requires the server to send
+	// "Accept-Ranges: bytes".
+	MaxResumes int
+
+	// Resume makes DownloadFile continue the content already in the file. It
+	// is the ETag or Last-Modified value of the response that wrote it, as
+	// reported by DownloadResult.Validator, and is sent as If-Range so the
+	// server sends the full content again if it has changed. When empty,
+	// DownloadFile truncates the file and starts from the beginning.
+	Resume string
+}
+
+// DownloadResult describes a completed download.
+type DownloadResult struct {
+	StatusCode int
+	Header     http.Header
+	Written    int64
+	SHA256     string
+	Resumes    int
+
+	// Validator is the ETag, or else the Last-Modified value, of the content.
+	// Pass it as DownloadRequest.Resume to continue an interrupted file
+	// download in a later call.
+	Validator string
+}
+
+// DownloadCaller streams response bodies to an io.Writer or a file without
+// buffering them in memory.
+type DownloadCaller struct {
+	caller *Caller[struct{}, struct{}]
+}
+
+func NewDownloadCaller(
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *DownloadCaller {
+	return &DownloadCaller{
+		caller: NewCaller[struct{}, struct{}](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+// Download streams the response body to w.
+func (h *DownloadCaller) Download(ctx context.Context, w io.Writer, req DownloadRequest, optional ...CallOption) (DownloadResult, error) {
+	return h.download(ctx, &downloadTarget{w: w, hash: sha256.New()}, req, optional...)
+}
+
+// DownloadFile streams the response body to the file at path. Unless
+// req.Resume is set, the file is truncated first. With req.Resume, the
+// download continues from the end of the file with a Range request; the file
+// is truncated if the server sends the full content instead.
+func (h *DownloadCaller) DownloadFile(ctx context.Context, path string, req DownloadRequest, optional ...CallOption) (DownloadResult, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return DownloadResult{}, fmt.Errorf("open download file: %w", err)
+	}
+	defer file.Close()
+
+	target := &downloadTarget{w: file, hash: sha256.New()}
+	target.reset = func() error {
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+		_, err := file.Seek(0, io.SeekStart)
+		return err
+	}
+	if req.Resume == "" {
+		if err := target.reset(); err != nil {
+			return DownloadResult{}, fmt.Errorf("reset download: %w", err)
+		}
+	} else {
+		written, err := io.Copy(target.hash, file)
+		if err != nil {
+			return DownloadResult{}, fmt.Errorf("read download file: %w", err)
+		}
+		target.written = written
+	}
+
+	return h.download(ctx, target, req, optional...)
+}
+
+// downloadTarget tracks what has been written so far. reset is nil when the
+// writer cannot be rewound.
+type downloadTarget struct {
+	w       io.Writer
+	hash    hash.Hash
+	written int64
+	reset   func() error
+}
+
+func (h *DownloadCaller) download(ctx context.Context, target *downloadTarget, req DownloadRequest, optional ...CallOption) (DownloadResult, error) {
+	c := h.caller
+	var result DownloadResult
+
+	requestURL, headers, err := c.prepare(nil, optional...)
+	if err != nil {
+		return result, err
+	}
+
+	// Within a call, an interrupted download is only resumed once a response
+	// has advertised range support.
+	var acceptRanges bool
+	var expected string
+	validator := req.Resume
+	for {
+		offset := target.written
+		serverResponse, err := h.request(ctx, requestURL, headers, offset, validator)
+		if err != nil {
+			return result, err
+		}
+
+		result.StatusCode = serverResponse.StatusCode
+		result.Header = serverResponse.Header
+		if serverResponse.Header.Get("Accept-Ranges") == "bytes" {
+			acceptRanges = true
+		}
+		if serverResponse.StatusCode == http.StatusOK {
+			validator = responseValidator(serverResponse.Header)
+		}
+		result.Validator = validator
+		if expected == "" && req.ChecksumHeader != "" {
+			expected = serverResponse.Header.Get(req.ChecksumHeader)
+		}
+
+		total := int64(-1)
+		switch {
+		case offset > 0 && serverResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable && validator != "" && contentRangeTotal(serverResponse.Header) == offset:
+			serverResponse.Body.Close()
+			return h.finish(target, req, expected, result)
+		case offset > 0 && serverResponse.StatusCode == http.StatusOK:
+			if target.reset == nil {
+				serverResponse.Body.Close()
+				return result, fmt.Errorf("%w: server ignored range request", ErrReadBody)
+			}
+			if err := target.reset(); err != nil {
+				serverResponse.Body.Close()
+				return result, fmt.Errorf("reset download: %w", err)
+			}
+			target.hash.Reset()
+			target.written = 0
+		case offset > 0 && serverResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+			serverResponse.Body.Close()
+			return result, fmt.Errorf("%w: range not satisfiable at offset %d", ErrReadBody, offset)
+		}
+
+		if serverResponse.StatusCode == http.StatusPartialContent {
+			// A range starting elsewhere than the offset cannot be appended;
+			// the download restarts without a Range request instead.
+			if start := contentRangeStart(serverResponse.Header); start != offset {
+				serverResponse.Body.Close()
+				if offset == 0 || target.reset == nil {
+					return result, fmt.Errorf("%w: content range starts at %d, expected %d", ErrReadBody, start, offset)
+				}
+				if err := target.reset(); err != nil {
+					return result, fmt.Errorf("reset download: %w", err)
+				}
+				target.hash.Reset()
+				target.written = 0
+				continue
+			}
+			total = contentRangeTotal(serverResponse.Header)
+		} else if serverResponse.ContentLength >= 0 {
+			total = target.written + serverResponse.ContentLength
+		}
+
+		err = h.copy(target, serverResponse.Body, total, req.Progress)
+		serverResponse.Body.Close()
+		if err == nil {
+			return h.finish(target, req, expected, result)
+		}
+
+		var writeErr *downloadWriteError
+		if errors.As(err, &writeErr) || errors.Is(err, errContentTooLong) || !acceptRanges || result.Resumes >= req.MaxResumes || ctx.Err() != nil {
+			return result, err
+		}
+		result.Resumes++
+	}
+}
+
+func (h *DownloadCaller) request(ctx context.Context, requestURL string, headers map[string]string, offset int64, validator string) (*http.Response, error) {
+	header := streamHeaders(headers)
+	var acceptStatus func(statusCode int) bool
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if validator != "" {
+			header.Set("If-Range", validator)
+		}
+		acceptStatus = func(statusCode int) bool {
+			return statusCode == http.StatusRequestedRangeNotSatisfiable
+		}
+	}
+
+	return h.caller.openStream(ctx, http.MethodGet, requestURL, header, nil, acceptStatus)
+}
+
+// responseValidator returns the strong ETag of a response, or else its
+// Last-Modified date. Weak ETags cannot be used with If-Range.
+func responseValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// errContentTooLong marks responses that carry more content than their
+// length, which resuming cannot fix.
+var errContentTooLong = errors.New("content longer than expected")
+
+// downloadWriteError marks failures of the destination writer, which cannot
+// be fixed by resuming.
+type downloadWriteError struct {
+	err error
+}
+
+func (e *downloadWriteError) Error() string {
+	return "write download: " + e.err.Error()
+}
+
+func (e *downloadWriteError) Unwrap() error {
+	return e.err
+}
+
+func (h *DownloadCaller) copy(target *downloadTarget, body io.Reader, total int64, progress func(written, total int64)) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if _, err := target.w.Write(buf[:n]); err != nil {
+				return &downloadWriteError{err: err}
+			}
+			target.hash.Write(buf[:n])
+			target.written += int64(n)
+			if total >= 0 && target.written > total {
+				return fmt.Errorf("%w: %w: %d bytes of %d", ErrReadBody, errContentTooLong, target.written, total)
+			}
+			if progress != nil {
+				progress(target.written, total)
+			}
+		}
+		if readErr == io.EOF {
+			if total >= 0 && target.written < total {
+				return fmt.Errorf("%w: %w", ErrReadBody, io.ErrUnexpectedEOF)
+			}
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("%w: %w", ErrReadBody, readErr)
+		}
+	}
+}
+
+func (h *DownloadCaller) finish(target *downloadTarget, req DownloadRequest, expected string, result DownloadResult) (DownloadResult, error) {
+	sum := target.hash.Sum(nil)
+	result.Written = target.written
+	result.SHA256 = hex.EncodeToString(sum)
+
+	if req.ExpectedSHA256 != "" {
+		expected = req.ExpectedSHA256
+	}
+	if expected != "" && !checksumMatches(expected, sum) {
+		return result, fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, result.SHA256)
+	}
+	return result, nil
+}
+
+// checksumMatches compares a hex or base64 encoded digest, optionally
+// prefixed with "sha-256=" as in the Digest header, against sum.
+func checksumMatches(expected string, sum []byte) bool {
+	expected = strings.TrimSpace(expected)
+	if prefix, value, ok := strings.Cut(expected, "="); ok && strings.EqualFold(prefix, "sha-256") {
+		expected = strings.Trim(value, ":")
+	}
+	if decoded, err := hex.DecodeString(expected); err == nil && len(decoded) == sha256.Size {
+		return string(decoded) == string(sum)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(expected); err == nil {
+		return string(decoded) == string(sum)
+	}
+	return false
+}
+
+// contentRangeStart returns the first byte position from a Content-Range
+// header, or -1 when it is missing or unsatisfied.
+func contentRangeStart(header http.Header) int64 {
+	contentRange, ok := strings.CutPrefix(header.Get("Content-Range"), "bytes ")
+	if !ok {
+		return -1
+	}
+	first, _, ok := strings.Cut(contentRange, "-")
+	if !ok {
+		return -1
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return start
+}
+
+// contentRangeTotal returns the complete length from a Content-Range header,
+// or -1 when it is missing or unknown.
+func contentRangeTotal(header http.Header) int64 {
+	contentRange := header.Get("Content-Range")
+	i := strings.LastIndexByte(contentRange, '/')
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
@@ -0,0 +1,366 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadCaller(t *testing.T) {
+	content := strings.Repeat("0123456789", 10000)
+	sum := sha256.Sum256([]byte(content))
+	digest := hex.EncodeToString(sum[:])
+
+	t.Run("Successful download with progress and checksum header", func(t *testing.T) {
+		transport := &rangeTransport{content: content, header: http.Header{"X-Checksum-Sha256": {digest}}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "exports/:id")
+
+		var lastWritten, lastTotal int64
+		var buf bytes.Buffer
+		result, err := caller.Download(context.Background(), &buf, DownloadRequest{
+			ChecksumHeader: "X-Checksum-Sha256",
+			Progress: func(written, total int64) {
+				lastWritten, lastTotal = written, total
+			},
+		}, CallOption{PathParam: map[string]string{"id": "42"}})
+		assert.NoError(t, err)
+		assert.Equal(t, content, buf.String())
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, digest, result.SHA256)
+		assert.Equal(t, int64(len(content)), lastWritten)
+		assert.Equal(t, int64(len(content)), lastTotal)
+		assert.Equal(t, "/exports/42", transport.paths[0])
+	})
+
+	t.Run("Failed download due to checksum mismatch", func(t *testing.T) {
+		caller := NewDownloadCaller(&http.Client{Transport: &rangeTransport{content: content}}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{
+			ExpectedSHA256: strings.Repeat("0", 64),
+		})
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("Successful download resumed with range requests", func(t *testing.T) {
+		transport := &rangeTransport{content: content, acceptRanges: true, failAfter: []int{30000, 30000}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		var buf bytes.Buffer
+		result, err := caller.Download(context.Background(), &buf, DownloadRequest{
+			ExpectedSHA256: digest,
+			MaxResumes:     2,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Resumes)
+		assert.Equal(t, content, buf.String())
+		assert.Equal(t, []string{"", "bytes=30000-", "bytes=60000-"}, transport.ranges)
+	})
+
+	t.Run("Failed download when resumes are exhausted", func(t *testing.T) {
+		transport := &rangeTransport{content: content, acceptRanges: true, failAfter: []int{100, 100}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{MaxResumes: 1})
+		assert.ErrorIs(t, err, ErrReadBody)
+		assert.Len(t, transport.ranges, 2)
+	})
+
+	t.Run("Failed download without range support is not resumed", func(t *testing.T) {
+		transport := &rangeTransport{content: content, failAfter: []int{100}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{MaxResumes: 3})
+		assert.ErrorIs(t, err, ErrReadBody)
+		assert.Len(t, transport.ranges, 1)
+	})
+
+	t.Run("Successful file download resumes a partial file with a validator", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte(content[:12345]), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true, etag: `"v1"`}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{
+			ExpectedSHA256: digest,
+			Resume:         `"v1"`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, digest, result.SHA256)
+		assert.Equal(t, `"v1"`, result.Validator)
+		assert.Equal(t, []string{"bytes=12345-"}, transport.ranges)
+		assert.Equal(t, []string{`"v1"`}, transport.ifRanges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Successful file download restarts a partial file without a validator", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte(content[:12345]), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true, etag: `"v1"`}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{ExpectedSHA256: digest})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, `"v1"`, result.Validator)
+		assert.Equal(t, []string{""}, transport.ranges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Successful file download restarts when the validator is stale", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte("stale data"), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true, etag: `"v2"`}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{
+			ExpectedSHA256: digest,
+			Resume:         `"v1"`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, `"v2"`, result.Validator)
+		assert.Equal(t, []string{"bytes=10-"}, transport.ranges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Successful file download restarts when range is ignored", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte("stale data"), 0o644))
+
+		transport := &rangeTransport{content: content, header: http.Header{"Digest": {"sha-256=" + base64.StdEncoding.EncodeToString(sum[:])}}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{
+			ChecksumHeader: "Digest",
+			Resume:         "Wed, 21 Oct 2015 07:28:00 GMT",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, []string{"bytes=10-"}, transport.ranges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Successful file download when file is already complete", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true, etag: `"v1"`}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{
+			ExpectedSHA256: digest,
+			Resume:         `"v1"`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, result.StatusCode)
+		assert.Equal(t, []string{fmt.Sprintf("bytes=%d-", len(content))}, transport.ranges)
+	})
+
+	t.Run("Successful file download replaces a complete file without a validator", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte(content+"trailing"), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{ExpectedSHA256: digest})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, []string{""}, transport.ranges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Failed download when a resumed range starts elsewhere", func(t *testing.T) {
+		transport := &rangeTransport{content: content, acceptRanges: true, rangeFromStart: true, failAfter: []int{30000}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		var buf bytes.Buffer
+		_, err := caller.Download(context.Background(), &buf, DownloadRequest{MaxResumes: 2})
+		assert.ErrorIs(t, err, ErrReadBody)
+		assert.ErrorContains(t, err, "content range starts at 0, expected 30000")
+		assert.Equal(t, 30000, buf.Len())
+		assert.Equal(t, []string{"", "bytes=30000-"}, transport.ranges)
+	})
+
+	t.Run("Successful file download restarts when a resumed range starts elsewhere", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		assert.NoError(t, os.WriteFile(path, []byte(content[:12345]), 0o644))
+
+		transport := &rangeTransport{content: content, acceptRanges: true, rangeFromStart: true, etag: `"v1"`}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		result, err := caller.DownloadFile(context.Background(), path, DownloadRequest{
+			ExpectedSHA256: digest,
+			Resume:         `"v1"`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), result.Written)
+		assert.Equal(t, []string{"bytes=12345-", ""}, transport.ranges)
+
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Failed download when content exceeds its length", func(t *testing.T) {
+		var requests int
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{
+				StatusCode:    http.StatusPartialContent,
+				Header:        http.Header{"Accept-Ranges": {"bytes"}, "Content-Range": {"bytes 0-9/10"}},
+				ContentLength: -1,
+				Body:          io.NopCloser(strings.NewReader("01230123456789")),
+			}, nil
+		})
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{MaxResumes: 2})
+		assert.ErrorIs(t, err, ErrReadBody)
+		assert.ErrorIs(t, err, errContentTooLong)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Failed download due to non-2xx status", func(t *testing.T) {
+		caller := NewDownloadCaller(
+			&http.Client{Transport: &mockTransport{mockResponseBody: `{"code": "E404"}`, statusCode: http.StatusNotFound}},
+			"https://example.com",
+			"export",
+			CallerOptions{ErrorBody: WithErrorBody[apiError]()},
+		)
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{})
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+		errBody, ok := ErrorBodyAs[apiError](err)
+		assert.True(t, ok)
+		assert.Equal(t, "E404", errBody.Code)
+	})
+
+	t.Run("Failed download is not resumed after partial content without range support", func(t *testing.T) {
+		transport := &rangeTransport{content: content, partial: true, failAfter: []int{100}}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), io.Discard, DownloadRequest{MaxResumes: 3})
+		assert.ErrorIs(t, err, ErrReadBody)
+		assert.Len(t, transport.ranges, 1)
+	})
+
+	t.Run("Failed download due to writer error", func(t *testing.T) {
+		transport := &rangeTransport{content: content, acceptRanges: true}
+		caller := NewDownloadCaller(&http.Client{Transport: transport}, "https://example.com", "export")
+
+		_, err := caller.Download(context.Background(), failingWriter{}, DownloadRequest{MaxResumes: 3})
+		assert.ErrorIs(t, err, errDiskFull)
+		assert.Len(t, transport.ranges, 1)
+	})
+}
+
+var errDiskFull = errors.New("disk full")
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errDiskFull
+}
+
+// Range transport serving content with optional Range and If-Range support
+// and scripted mid-stream failures. partial serves every response as 206
+// without advertising Accept-Ranges; rangeFromStart answers range requests
+// with the content from its first byte.
+type rangeTransport struct {
+	content        string
+	acceptRanges   bool
+	partial        bool
+	rangeFromStart bool
+	etag           string
+	header         http.Header
+	failAfter      []int
+	ranges         []string
+	ifRanges       []string
+	paths          []string
+}
+
+func (r *rangeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := len(r.ranges)
+	r.ranges = append(r.ranges, req.Header.Get("Range"))
+	if ifRange := req.Header.Get("If-Range"); ifRange != "" {
+		r.ifRanges = append(r.ifRanges, ifRange)
+	}
+	r.paths = append(r.paths, req.URL.Path)
+
+	header := http.Header{}
+	for key, values := range r.header {
+		header[key] = values
+	}
+	if r.etag != "" {
+		header.Set("ETag", r.etag)
+	}
+	statusCode := http.StatusOK
+	body := r.content
+
+	if r.partial {
+		statusCode = http.StatusPartialContent
+		header.Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(r.content)-1, len(r.content)))
+	}
+	if r.acceptRanges {
+		header.Set("Accept-Ranges", "bytes")
+		ifRange := req.Header.Get("If-Range")
+		if rangeHeader := req.Header.Get("Range"); rangeHeader != "" && (ifRange == "" || ifRange == r.etag) {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			if r.rangeFromStart {
+				start = 0
+			}
+			if start >= len(r.content) {
+				header.Set("Content-Range", fmt.Sprintf("bytes */%d", len(r.content)))
+				return &http.Response{
+					StatusCode: http.StatusRequestedRangeNotSatisfiable,
+					Header:     header,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+			statusCode = http.StatusPartialContent
+			header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(r.content)-1, len(r.content)))
+			body = r.content[start:]
+		}
+	}
+
+	var reader io.Reader = strings.NewReader(body)
+	if attempt < len(r.failAfter) {
+		reader = io.MultiReader(strings.NewReader(body[:r.failAfter[attempt]]), &errorReader{})
+	}
+
+	return &http.Response{
+		StatusCode:    statusCode,
+		Header:        header,
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(reader),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package httpcaller
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Envelope describes how responses wrap their payload, e.g.
+//
+//	Envelope: &httpcaller.Envelope{
+//		Success: map[string]interface{}{"status": "success"},
+//	}
+//
+// unwraps {"status": "success", "data": {...}} into the response type of the
+// data object. Paths use the syntax of Expect.
+type Envelope struct {
+	// Success is matched against the envelope like
+	// CallerOptions.BaseSuccessResponse.
+	Success map[string]interface{}
+
+	// Rules are further checks on the envelope.
+	Rules []SuccessRule
+
+	// DataPath selects the payload decoded into the response. Defaults to
+	// "data". A missing or null payload leaves the response zero.
+	DataPath string
+
+	// CodePath and MessagePath select the fields reported in EnvelopeError
+	// when validation fails. They default to "code" and "message".
+	CodePath    string
+	MessagePath string
+}
+
+func (e *Envelope) unwrap(codec Codec, body []byte, v any) error {
+	body, err := jsonBody(codec, body)
+	if err != nil {
+		return err
+	}
+
+	if err := e.validate(body); err != nil {
+		return &EnvelopeError{
+			Code:    envelopeField(body, e.CodePath, "code"),
+			Message: envelopeField(body, e.MessagePath, "message"),
+			Err:     err,
+		}
+	}
+
+	dataPath, err := parsePath(pathOrDefault(e.DataPath, "data"))
+	if err != nil {
+		return fmt.Errorf("envelope data path %q: %w", e.DataPath, err)
+	}
+	data, err := lookupJSON(body, dataPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return nil
+}
+
+func (e *Envelope) validate(body []byte) error {
+	if len(e.Success) > 0 {
+		if err := matchBaseSuccessResponse(JSONCodec, body, e.Success); err != nil {
+			return err
+		}
+	}
+	if len(e.Rules) > 0 {
+		return matchSuccessRules(JSONCodec, body, e.Rules)
+	}
+	return nil
+}
+
+// envelopeField returns the value at path as text: strings unquoted, other
+// values as raw JSON. It is empty when the value is missing or null.
+func envelopeField(body []byte, path string, defaultPath string) string {
+	segments, err := parsePath(pathOrDefault(path, defaultPath))
+	if err != nil {
+		return ""
+	}
+	raw, err := lookupJSON(body, segments)
+	if err != nil || raw == nil || string(raw) == "null" {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	return string(raw)
+}
+
+func pathOrDefault(path string, defaultPath string) string {
+	if path == "" {
+		return defaultPath
+	}
+	return path
+}
@@ -0,0 +1,165 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type envelopeUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestEnvelope(t *testing.T) {
+	t.Run("Successful GET request unwraps the data field", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"status": "success", "data": {"id": 1, "name": "Ada"}}`,
+			},
+		}
+
+		caller := NewGetCaller[envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/1",
+			CallerOptions{
+				Envelope: &Envelope{
+					Success: map[string]interface{}{"status": "success"},
+				},
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, envelopeUser{ID: 1, Name: "Ada"}, res)
+	})
+
+	t.Run("Successful GET request with custom data path and rules", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"meta": {"code": 0}, "result": {"user": {"id": 2, "name": "Grace"}}}`,
+			},
+		}
+
+		caller := NewGetCaller[envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/2",
+			CallerOptions{
+				Envelope: &Envelope{
+					Rules:    []SuccessRule{Expect("meta.code").In(0, 200)},
+					DataPath: "result.user",
+				},
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, envelopeUser{ID: 2, Name: "Grace"}, res)
+	})
+
+	t.Run("Successful GET request with null data", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"status": "success", "data": null}`,
+			},
+		}
+
+		caller := NewGetCaller[*envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/3",
+			CallerOptions{
+				Envelope: &Envelope{
+					Success: map[string]interface{}{"status": "success"},
+				},
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Failed GET request surfaces envelope code and message", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"status": "error", "code": 4041, "message": "user not found", "data": null}`,
+			},
+		}
+
+		caller := NewGetCaller[envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/4",
+			CallerOptions{
+				Envelope: &Envelope{
+					Success: map[string]interface{}{"status": "success"},
+				},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		var envelopeErr *EnvelopeError
+		assert.True(t, errors.As(err, &envelopeErr))
+		assert.Equal(t, "4041", envelopeErr.Code)
+		assert.Equal(t, "user not found", envelopeErr.Message)
+		assert.Equal(t, "unsuccessful response for key status: expected success, got error [code 4041]: user not found", err.Error())
+	})
+
+	t.Run("Failed GET request with custom message path", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"ok": false, "error": {"reason": "quota exceeded"}}`,
+			},
+		}
+
+		caller := NewGetCaller[envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/5",
+			CallerOptions{
+				Envelope: &Envelope{
+					Rules:       []SuccessRule{Expect("ok").Equal(true)},
+					MessagePath: "error.reason",
+				},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+
+		var envelopeErr *EnvelopeError
+		assert.True(t, errors.As(err, &envelopeErr))
+		assert.Empty(t, envelopeErr.Code)
+		assert.Equal(t, "quota exceeded", envelopeErr.Message)
+	})
+
+	t.Run("Failed GET request due to undecodable data", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"status": "success", "data": {"id": "one"}}`,
+			},
+		}
+
+		caller := NewGetCaller[envelopeUser](
+			mockClient,
+			"https://example.com",
+			"users/6",
+			CallerOptions{
+				Envelope: &Envelope{
+					Success: map[string]interface{}{"status": "success"},
+				},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrDecode)
+		assert.Contains(t, err.Error(), "unmarshal response error")
+	})
+}
@@ -0,0 +1,105 @@
+package httpcaller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors identifying the stage of the pipeline that failed. They are
+// wrapped together with the underlying cause, so errors.Is and errors.As work
+// for both, e.g. errors.Is(err, ErrTransport) and
+// errors.Is(err, context.DeadlineExceeded).
+var (
+	ErrEncode               = errors.New("marshal request error")
+	ErrCreateRequest        = errors.New("create request error")
+	ErrPathParam            = errors.New("path parameter error")
+	ErrTransport            = errors.New("request error")
+	ErrReadBody             = errors.New("read response error")
+	ErrDecode               = errors.New("unmarshal response error")
+	ErrUnsuccessfulResponse = errors.New("unsuccessful response")
+	ErrCircuitOpen          = errors.New("circuit breaker is open")
+	ErrChecksumMismatch     = errors.New("checksum mismatch")
+)
+
+// HTTPError is returned when the server responds with a status code that the
+// caller's success-status policy rejects.
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	// ErrorBody holds Body decoded by the caller's ErrorBodyDecoder. It is
+	// nil when no decoder is configured or the body could not be decoded.
+	ErrorBody any
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("unexpected status %d from %s %s", e.StatusCode, e.Method, e.URL)
+	if bodyErr, ok := e.ErrorBody.(error); ok {
+		msg += ": " + bodyErr.Error()
+	}
+	return msg
+}
+
+// Unwrap makes every HTTPError match ErrUnsuccessfulResponse.
+func (e *HTTPError) Unwrap() error {
+	return ErrUnsuccessfulResponse
+}
+
+// EnvelopeError is returned when a response envelope fails validation. It
+// carries the envelope's code and message fields, when present.
+type EnvelopeError struct {
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *EnvelopeError) Error() string {
+	msg := e.Err.Error()
+	if e.Code != "" {
+		msg += " [code " + e.Code + "]"
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns the validation error, which wraps ErrUnsuccessfulResponse.
+func (e *EnvelopeError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorBodyDecoder decodes the body of an unsuccessful response into a typed
+// value that is exposed as HTTPError.ErrorBody. codec is the response codec
+// selected for the response Content-Type.
+type ErrorBodyDecoder func(codec Codec, body []byte) (any, error)
+
+// WithErrorBody returns an ErrorBodyDecoder that decodes unsuccessful
+// response bodies into E with the response codec. Use ErrorBodyAs to read
+// the value back.
+func WithErrorBody[E any]() ErrorBodyDecoder {
+	return func(codec Codec, body []byte) (any, error) {
+		var errBody E
+		if err := codec.Decode(bytes.NewReader(body), &errBody); err != nil {
+			return nil, err
+		}
+		return errBody, nil
+	}
+}
+
+// ErrorBodyAs returns the decoded error body carried by an *HTTPError in
+// err's chain if it has type E.
+func ErrorBodyAs[E any](err error) (E, bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		errBody, ok := httpErr.ErrorBody.(E)
+		return errBody, ok
+	}
+	var zero E
+	return zero, false
+}
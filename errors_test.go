@@ -0,0 +1,261 @@
+package httpcaller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type apiError struct {
+	Code    string `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+}
+
+type apiErrorWithMessage struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e apiErrorWithMessage) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func TestErrorBody(t *testing.T) {
+	t.Run("Decodes error body into typed value", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"code": "E123", "message": "invalid account"}`,
+				statusCode:       http.StatusBadRequest,
+			},
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				ErrorBody: WithErrorBody[apiError](),
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.Error(t, err)
+
+		errBody, ok := ErrorBodyAs[apiError](err)
+		assert.True(t, ok)
+		assert.Equal(t, "E123", errBody.Code)
+		assert.Equal(t, "invalid account", errBody.Message)
+	})
+
+	t.Run("Decodes error body with the response codec", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Header:     http.Header{"Content-Type": []string{"application/xml"}},
+					Body:       io.NopCloser(strings.NewReader(`<error><code>E123</code><message>invalid account</message></error>`)),
+				}, nil
+			}),
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				ResponseCodec: XMLCodec,
+				ErrorBody:     WithErrorBody[apiError](),
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.Error(t, err)
+
+		errBody, ok := ErrorBodyAs[apiError](err)
+		assert.True(t, ok)
+		assert.Equal(t, "E123", errBody.Code)
+		assert.Equal(t, "invalid account", errBody.Message)
+	})
+
+	t.Run("Includes error body message when it implements error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"code": "E123", "message": "invalid account"}`,
+				statusCode:       http.StatusBadRequest,
+			},
+		}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				ErrorBody: WithErrorBody[apiErrorWithMessage](),
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, map[string]interface{}{"test": "data"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "E123: invalid account")
+	})
+
+	t.Run("Keeps raw body when error body cannot be decoded", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `<html>bad gateway</html>`,
+				statusCode:       http.StatusBadGateway,
+			},
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				ErrorBody: WithErrorBody[apiError](),
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.Error(t, err)
+
+		_, ok := ErrorBodyAs[apiError](err)
+		assert.False(t, ok)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, "<html>bad gateway</html>", string(httpErr.Body))
+	})
+
+	t.Run("Reports no error body for non HTTP errors", func(t *testing.T) {
+		_, ok := ErrorBodyAs[apiError](errors.New("network error"))
+		assert.False(t, ok)
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Run("Wraps transport errors", func(t *testing.T) {
+		opErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, opErr
+			}),
+		}
+
+		caller := NewGetCaller[map[string]interface{}](mockClient, "https://example.com", "test")
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrTransport)
+
+		var target *net.OpError
+		assert.True(t, errors.As(err, &target))
+		assert.Equal(t, "dial", target.Op)
+	})
+
+	t.Run("Wraps context deadline exceeded", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}),
+		}
+
+		caller := NewGetCaller[map[string]interface{}](mockClient, "https://example.com", "test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err := caller.Get(ctx)
+		assert.ErrorIs(t, err, ErrTransport)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Wraps encode errors", func(t *testing.T) {
+		caller := NewPostCaller[chan int, map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"https://example.com",
+			"test",
+		)
+
+		_, err := caller.Post(context.Background(), make(chan int))
+		assert.ErrorIs(t, err, ErrEncode)
+
+		var target *json.UnsupportedTypeError
+		assert.True(t, errors.As(err, &target))
+	})
+
+	t.Run("Wraps create request errors", func(t *testing.T) {
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"://bad-url",
+			"test",
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrCreateRequest)
+	})
+
+	t.Run("Wraps read and decode errors", func(t *testing.T) {
+		readCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{readError: true}},
+			"https://example.com",
+			"test",
+		)
+		_, err := readCaller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrReadBody)
+
+		decodeCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{unmarshalError: true}},
+			"https://example.com",
+			"test",
+		)
+		_, err = decodeCaller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrDecode)
+
+		var target *json.SyntaxError
+		assert.True(t, errors.As(err, &target))
+	})
+
+	t.Run("Marks unsuccessful responses", func(t *testing.T) {
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "success",
+				},
+			},
+		)
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		statusCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{mockResponseBody: `{}`, statusCode: http.StatusServiceUnavailable}},
+			"https://example.com",
+			"test",
+		)
+		_, err = statusCaller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+	})
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -2,6 +2,7 @@ package httpcaller
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -208,4 +209,56 @@ func TestGetCaller(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unmarshal response error")
 	})
+
+	t.Run("Failed GET request due to non-2xx status", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"code": "E500", "message": "boom"}`,
+				statusCode:       http.StatusInternalServerError,
+			},
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Get(ctx)
+		assert.Error(t, err)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+		assert.Equal(t, "GET", httpErr.Method)
+		assert.Equal(t, "https://example.com/test", httpErr.URL)
+		assert.Equal(t, "application/json", httpErr.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"code": "E500", "message": "boom"}`, string(httpErr.Body))
+	})
+
+	t.Run("Successful GET request with custom success status policy", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data"}`,
+				statusCode:       http.StatusNotFound,
+			},
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				IsSuccessStatus: func(statusCode int) bool {
+					return statusCode < 500
+				},
+			},
+		)
+
+		ctx := context.Background()
+		res, err := caller.Get(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
 }
@@ -0,0 +1,125 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	t.Run("Successful request calls request and response hooks", func(t *testing.T) {
+		var responses []string
+		transport := &recordingTransport{}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				OnRequest: func(req *http.Request) {
+					req.Header.Set("X-Correlation-ID", "abc-123")
+				},
+				OnResponse: func(res *http.Response, body []byte) {
+					responses = append(responses, string(body))
+				},
+			},
+		)
+
+		res, err := caller.Post(context.Background(), map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, "abc-123", transport.request.Header.Get("X-Correlation-ID"))
+		assert.Equal(t, "application/json", transport.request.Header.Get("Content-Type"))
+		assert.Equal(t, []string{`{"test": "data"}`}, responses)
+	})
+
+	t.Run("Successful retried request calls retry hook per retry", func(t *testing.T) {
+		type retry struct {
+			attempt int
+			status  int
+		}
+		var retries []retry
+		var requests, responses int
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &sequenceTransport{statusCodes: []int{503, 502, 200}}},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+				Clock:       newFakeClock(),
+				OnRequest: func(req *http.Request) {
+					requests++
+				},
+				OnResponse: func(res *http.Response, body []byte) {
+					responses++
+				},
+				OnRetry: func(attempt int, err error) {
+					status := 0
+					if httpErr, ok := err.(*HTTPError); ok {
+						status = httpErr.StatusCode
+					}
+					retries = append(retries, retry{attempt, status})
+				},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []retry{{1, 503}, {2, 502}}, retries)
+		assert.Equal(t, 3, requests)
+		assert.Equal(t, 3, responses)
+	})
+
+	t.Run("Failed request calls decode error hook with the raw body", func(t *testing.T) {
+		var raw []byte
+		var decodeErr error
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{unmarshalError: true}},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				OnDecodeError: func(body []byte, err error) {
+					raw, decodeErr = body, err
+				},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrDecode)
+		assert.Equal(t, "{invalid json}", string(raw))
+		assert.Equal(t, err, decodeErr)
+	})
+
+	t.Run("Failed stream calls decode error hook with the raw line", func(t *testing.T) {
+		var raw []byte
+		var responses int
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: &streamTransport{body: "{\"id\":1}\nnot json\n"}},
+			"https://example.com",
+			"events",
+			CallerOptions{
+				OnResponse: func(res *http.Response, body []byte) {
+					responses++
+					assert.Nil(t, body)
+				},
+				OnDecodeError: func(line []byte, err error) {
+					raw = line
+					assert.ErrorIs(t, err, ErrDecode)
+				},
+			},
+		)
+
+		stream, err := caller.Stream(context.Background())
+		assert.NoError(t, err)
+		for stream.Next() {
+		}
+		assert.ErrorIs(t, stream.Err(), ErrDecode)
+		assert.Equal(t, "not json", string(raw))
+		assert.Equal(t, 1, responses)
+	})
+}
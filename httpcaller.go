@@ -1,11 +1,119 @@
 package httpcaller
 
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
 type CallerOptions struct {
 	DefaultHeaders      map[string]string
+	DefaultQuery        url.Values
 	BaseSuccessResponse map[string]interface{}
+
+	// SuccessRules are checked against the body of successful responses
+	// after BaseSuccessResponse, e.g. Expect("data.status").Equal("ok").
+	SuccessRules []SuccessRule
+
+	// Envelope validates a response envelope such as
+	// {"status": "success", "data": {...}} and decodes only its payload into
+	// the response.
+	Envelope *Envelope
+
+	// IsSuccessStatus reports whether a response status code counts as a
+	// success. Responses that fail it are returned as *HTTPError. Defaults
+	// to accepting any 2xx status.
+	IsSuccessStatus func(statusCode int) bool
+
+	// ErrorBody decodes the body of unsuccessful responses into
+	// HTTPError.ErrorBody, e.g. WithErrorBody[APIError]().
+	ErrorBody ErrorBodyDecoder
+
+	// RequestCodec encodes request bodies and sets their Content-Type.
+	// Defaults to JSONCodec.
+	RequestCodec Codec
+
+	// ResponseCodec decodes response bodies. When the response Content-Type
+	// names another media type, a matching codec from ResponseCodecs or the
+	// built-in JSON, XML and form codecs is used instead. Defaults to
+	// JSONCodec. BaseSuccessResponse, SuccessRules and Envelope require a codec
+	// that can decode into map[string]interface{}.
+	ResponseCodec  Codec
+	ResponseCodecs []Codec
+
+	// RetryPolicy retries failed attempts. Calls are attempted once when it
+	// is nil.
+	RetryPolicy *RetryPolicy
+
+	// RateLimitHeaders holds requests back while the server reports an
+	// exhausted quota through X-RateLimit-* or IETF RateLimit headers. The
+	// wait is capped by RetryPolicy.MaxRetryAfter.
+	RateLimitHeaders bool
+
+	// CircuitBreaker fails attempts fast with ErrCircuitOpen while the
+	// upstream is unhealthy. Share one breaker between callers to break per
+	// host.
+	CircuitBreaker *CircuitBreaker
+
+	// RateLimiter delays attempts until a token is available. Share one
+	// limiter between callers that target the same host.
+	RateLimiter *RateLimiter
+
+	// Clock is used for waiting between attempts. Defaults to the system
+	// clock; tests can inject a fake one.
+	Clock Clock
+
+	// Middlewares wrap every attempt, the first one outermost. See
+	// Middleware for the ordering and Chain for sharing a chain.
+	Middlewares []Middleware
+
+	// OnRequest is called with every attempt before it is sent, after the
+	// caller has set its headers, and may add headers of its own.
+	OnRequest func(req *http.Request)
+
+	// OnResponse is called with every response and its raw body. Setting it
+	// buffers successful bodies instead of decoding them as they stream in.
+	// body is nil for successful stream, SSE and download responses.
+	OnResponse func(res *http.Response, body []byte)
+
+	// OnDecodeError is called with the raw body, or the raw line or event
+	// data of a stream, when it cannot be decoded.
+	OnDecodeError func(raw []byte, err error)
+
+	// OnRetry is called before a failed attempt is retried, with the number
+	// of the attempt that failed and its error.
+	OnRetry func(attempt int, err error)
+
+	// Logger logs every attempt with its method, URL template, status,
+	// duration, attempt number and byte counts. Headers and bodies are
+	// logged at debug level. Logging is disabled when it is nil.
+	Logger *slog.Logger
+
+	// LogRedaction lists headers and body fields masked before anything is
+	// logged, on top of DefaultLogRedaction.
+	LogRedaction *LogRedaction
+
+	// TracerProvider creates a client span for every call, named after the
+	// method and endpoint template. Defaults to the global provider.
+	TracerProvider trace.TracerProvider
+
+	// Propagator injects the trace context into every attempt. Defaults to
+	// W3C Trace Context (traceparent and tracestate headers).
+	Propagator propagation.TextMapPropagator
 }
 
 type CallOption struct {
 	Header    map[string]string
 	PathParam map[string]string
+
+	// Query is merged over CallerOptions.DefaultQuery: a key set here
+	// replaces all default values for that key.
+	Query url.Values
+}
+
+func defaultIsSuccessStatus(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
 }
@@ -0,0 +1,210 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+
+	// maxLoggedBody caps the length of bodies logged at debug level.
+	maxLoggedBody = 4096
+)
+
+// LogRedaction lists the values that are masked before requests and
+// responses are logged, in addition to DefaultLogRedaction.
+type LogRedaction struct {
+	// Headers are header names, matched case-insensitively.
+	Headers []string
+
+	// Fields are JSON object keys, matched case-insensitively at any depth.
+	// They also apply to form-encoded bodies.
+	Fields []string
+
+	// ReplaceDefaults stops DefaultLogRedaction from being applied, so only
+	// the headers and fields listed here are masked.
+	ReplaceDefaults bool
+}
+
+// DefaultLogRedaction is always applied unless LogRedaction.ReplaceDefaults
+// is set.
+var DefaultLogRedaction = LogRedaction{
+	Headers: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+	Fields:  []string{"password"},
+}
+
+// logRedaction is a LogRedaction normalized for lookups.
+type logRedaction struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+func newLogRedaction(r LogRedaction) *logRedaction {
+	lr := &logRedaction{headers: make(map[string]bool), fields: make(map[string]bool)}
+	lr.add(r)
+	if !r.ReplaceDefaults {
+		lr.add(DefaultLogRedaction)
+	}
+	return lr
+}
+
+func (r *logRedaction) add(redaction LogRedaction) {
+	for _, header := range redaction.Headers {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, field := range redaction.Fields {
+		r.fields[strings.ToLower(field)] = true
+	}
+}
+
+func (r *logRedaction) header(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, values := range header {
+		if r.headers[http.CanonicalHeaderKey(key)] {
+			redacted[key] = []string{redactedValue}
+			continue
+		}
+		redacted[key] = values
+	}
+	return redacted
+}
+
+// body returns data as loggable text. JSON and form bodies are logged with
+// their redacted fields masked; other media types are only summarized, as
+// their contents cannot be redacted.
+func (r *logRedaction) body(contentType string, data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(data))
+		if err == nil {
+			for key := range values {
+				if r.fields[strings.ToLower(key)] {
+					values[key] = []string{redactedValue}
+				}
+			}
+			return truncateLogged(values.Encode())
+		}
+	case mediaType == "", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var value any
+		if err := dec.Decode(&value); err == nil {
+			redacted, err := json.Marshal(r.json(value))
+			if err == nil {
+				return truncateLogged(string(redacted))
+			}
+		}
+	}
+	if mediaType == "" {
+		mediaType = "unknown media type"
+	}
+	return fmt.Sprintf("<%d bytes of %s>", len(data), mediaType)
+}
+
+func (r *logRedaction) json(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.json(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.json(item)
+		}
+	}
+	return value
+}
+
+// loggedError returns the text of err with the expanded request URL, whose
+// path and query may carry secrets, replaced by the URL template.
+func (c *Caller[request, response]) loggedError(err error) string {
+	msg := err.Error()
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.URL != "" {
+		msg = strings.ReplaceAll(msg, httpErr.URL, c.route.template)
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.URL != "" {
+		msg = strings.ReplaceAll(msg, urlErr.URL, c.route.template)
+	}
+	return msg
+}
+
+func truncateLogged(s string) string {
+	if len(s) <= maxLoggedBody {
+		return s
+	}
+	return s[:maxLoggedBody] + "...(truncated)"
+}
+
+// logAttempt logs one attempt at info level, or error level when it failed,
+// using the URL template rather than the expanded URL. Headers and bodies are
+// logged in a second record at debug level. ex is nil when no response was
+// received.
+func (c *Caller[request, response]) logAttempt(ctx context.Context, method string, attempt int, duration time.Duration, headers http.Header, body *requestBody, ex *exchange, err error) {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", c.route.template),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	if ex != nil {
+		attrs = append(attrs, slog.Int("status", ex.statusCode))
+	}
+	if body != nil && body.open == nil {
+		attrs = append(attrs, slog.Int("request_bytes", len(body.data)))
+	}
+	if ex != nil && ex.received >= 0 {
+		attrs = append(attrs, slog.Int64("response_bytes", ex.received))
+	}
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", c.loggedError(err)))
+	}
+	c.logger.LogAttrs(ctx, level, "http request", attrs...)
+
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs = []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", c.route.template),
+		slog.Int("attempt", attempt),
+		slog.Any("request_headers", c.logRedaction.header(headers)),
+	}
+	if body != nil {
+		if body.open != nil {
+			attrs = append(attrs, slog.String("request_body", "<streamed>"))
+		} else {
+			attrs = append(attrs, slog.String("request_body", c.logRedaction.body(body.contentType, body.data)))
+		}
+	}
+	if ex != nil {
+		attrs = append(attrs, slog.Any("response_headers", c.logRedaction.header(ex.header)))
+		if ex.body != nil {
+			attrs = append(attrs, slog.String("response_body", c.logRedaction.body(ex.header.Get("Content-Type"), ex.body)))
+		}
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "http request body", attrs...)
+}
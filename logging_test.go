@@ -0,0 +1,220 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeLogRecords(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogging(t *testing.T) {
+	t.Run("Successful request logs the URL template and byte counts", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: &recordingTransport{}},
+			"https://example.com",
+			"users/:id",
+			CallerOptions{Logger: logger},
+		)
+
+		_, err := caller.Post(context.Background(), map[string]interface{}{"test": "data"}, CallOption{
+			PathParam: map[string]string{"id": "123"},
+		})
+		assert.NoError(t, err)
+
+		records := decodeLogRecords(t, &logs)
+		assert.Len(t, records, 1)
+		record := records[0]
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "http request", record["msg"])
+		assert.Equal(t, "POST", record["method"])
+		assert.Equal(t, "https://example.com/users/:id", record["url"])
+		assert.Equal(t, float64(200), record["status"])
+		assert.Equal(t, float64(1), record["attempt"])
+		assert.Equal(t, float64(len(`{"test":"data"}`)), record["request_bytes"])
+		assert.Equal(t, float64(len(`{"test": "data"}`)), record["response_bytes"])
+		assert.Contains(t, record, "duration")
+		assert.NotContains(t, logs.String(), "/users/123")
+	})
+
+	t.Run("Successful request logs redacted headers and bodies at debug level", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: &recordingTransport{}},
+			"https://example.com",
+			"payments",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer secret-token",
+					"X-Request-ID":  "req-1",
+				},
+				Logger: logger,
+				LogRedaction: &LogRedaction{
+					Headers: []string{"authorization"},
+					Fields:  []string{"password", "cardNumber"},
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"user":  map[string]interface{}{"name": "Ada", "Password": "hunter2"},
+			"cards": []interface{}{map[string]interface{}{"cardNumber": "4111111111111111"}},
+		}
+		res, err := caller.Post(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+
+		assert.NotContains(t, logs.String(), "secret-token")
+		assert.NotContains(t, logs.String(), "hunter2")
+		assert.NotContains(t, logs.String(), "4111111111111111")
+
+		records := decodeLogRecords(t, &logs)
+		assert.Len(t, records, 2)
+		debug := records[1]
+		assert.Equal(t, "DEBUG", debug["level"])
+		assert.Equal(t, map[string]interface{}{
+			"Authorization": []interface{}{"[REDACTED]"},
+			"Content-Type":  []interface{}{"application/json"},
+			"X-Request-Id":  []interface{}{"req-1"},
+		}, debug["request_headers"])
+		assert.JSONEq(t, `{"cards": [{"cardNumber": "[REDACTED]"}], "user": {"name": "Ada", "Password": "[REDACTED]"}}`, debug["request_body"].(string))
+		assert.JSONEq(t, `{"test": "data"}`, debug["response_body"].(string))
+	})
+
+	t.Run("Successful request applies default redaction with custom fields", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: &recordingTransport{}},
+			"https://example.com",
+			"payments",
+			CallerOptions{
+				DefaultHeaders: map[string]string{"Authorization": "Bearer secret-token"},
+				Logger:         logger,
+				LogRedaction:   &LogRedaction{Fields: []string{"cardNumber"}},
+			},
+		)
+
+		_, err := caller.Post(context.Background(), map[string]interface{}{
+			"password":   "hunter2",
+			"cardNumber": "4111111111111111",
+		})
+		assert.NoError(t, err)
+
+		assert.NotContains(t, logs.String(), "secret-token")
+		assert.NotContains(t, logs.String(), "hunter2")
+		assert.NotContains(t, logs.String(), "4111111111111111")
+
+		records := decodeLogRecords(t, &logs)
+		assert.Len(t, records, 2)
+		assert.Equal(t, []interface{}{"[REDACTED]"}, records[1]["request_headers"].(map[string]interface{})["Authorization"])
+	})
+
+	t.Run("Failed attempts are logged at error level", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &sequenceTransport{statusCodes: []int{503, 200}}},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+				Clock:       newFakeClock(),
+				Logger:      logger,
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+
+		records := decodeLogRecords(t, &logs)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, float64(503), records[0]["status"])
+		assert.Equal(t, float64(1), records[0]["attempt"])
+		assert.Contains(t, records[0]["error"], "unexpected status 503")
+		assert.Equal(t, "INFO", records[1]["level"])
+		assert.Equal(t, float64(2), records[1]["attempt"])
+	})
+
+	t.Run("Failed attempts are logged without the expanded URL", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+		statusCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &sequenceTransport{statusCodes: []int{503}}},
+			"https://example.com",
+			"users/:id",
+			CallerOptions{
+				DefaultQuery: url.Values{"api_key": {"s3cret"}},
+				Logger:       logger,
+			},
+		)
+		_, err := statusCaller.Get(context.Background(), CallOption{PathParam: map[string]string{"id": "123"}})
+		assert.Error(t, err)
+
+		transportCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			})},
+			"https://example.com",
+			"users/:id",
+			CallerOptions{
+				DefaultQuery: url.Values{"api_key": {"s3cret"}},
+				Logger:       logger,
+			},
+		)
+		_, err = transportCaller.Get(context.Background(), CallOption{PathParam: map[string]string{"id": "123"}})
+		assert.ErrorIs(t, err, ErrTransport)
+
+		assert.NotContains(t, logs.String(), "s3cret")
+		assert.NotContains(t, logs.String(), "/users/123")
+
+		records := decodeLogRecords(t, &logs)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "unexpected status 503 from GET https://example.com/users/:id", records[0]["error"])
+		assert.Contains(t, records[1]["error"], "https://example.com/users/:id")
+		assert.Contains(t, records[1]["error"], "connection refused")
+	})
+
+	t.Run("Redaction masks form fields and summarizes other bodies", func(t *testing.T) {
+		redaction := newLogRedaction(DefaultLogRedaction)
+
+		assert.Equal(t, "password=%5BREDACTED%5D&user=ada", redaction.body("application/x-www-form-urlencoded", []byte("user=ada&password=hunter2")))
+		assert.Equal(t, "<4 bytes of application/octet-stream>", redaction.body("application/octet-stream", []byte("\x00\x01\x02\x03")))
+		assert.Equal(t, "<8 bytes of unknown media type>", redaction.body("", []byte("not json")))
+		assert.Equal(t, []string{"[REDACTED]"}, redaction.header(http.Header{"Set-Cookie": {"session=1"}})["Set-Cookie"])
+	})
+
+	t.Run("Redaction replaces the defaults only when asked to", func(t *testing.T) {
+		redaction := newLogRedaction(LogRedaction{Headers: []string{"X-Api-Key"}, ReplaceDefaults: true})
+
+		header := redaction.header(http.Header{"Authorization": {"Bearer token"}, "X-Api-Key": {"key"}})
+		assert.Equal(t, []string{"Bearer token"}, header["Authorization"])
+		assert.Equal(t, []string{"[REDACTED]"}, header["X-Api-Key"])
+		assert.JSONEq(t, `{"password": "hunter2"}`, redaction.body("application/json", []byte(`{"password": "hunter2"}`)))
+	})
+}
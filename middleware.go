@@ -0,0 +1,29 @@
+package httpcaller
+
+import "net/http"
+
+// Handler sends a prepared request and returns its response. The innermost
+// handler is the caller's http.Client.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler with cross-cutting behaviour such as auth,
+// logging or metrics. It runs once per attempt, so retried calls pass
+// through it again.
+//
+// Middlewares listed first are outermost: in
+//
+//	Middlewares: []httpcaller.Middleware{auth, logging}
+//
+// auth sees the request before logging does and sees the response after it.
+type Middleware func(next Handler) Handler
+
+// Chain combines middlewares into one, preserving their order, so a chain
+// can be built once and shared across callers.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
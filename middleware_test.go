@@ -0,0 +1,150 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewares(t *testing.T) {
+	t.Run("Successful requests run middlewares in order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+" request")
+					res, err := next(req)
+					calls = append(calls, name+" response")
+					return res, err
+				}
+			}
+		}
+
+		transport := &recordingTransport{}
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				Middlewares: []Middleware{trace("outer"), Chain(trace("middle"), trace("inner"))},
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, []string{
+			"outer request",
+			"middle request",
+			"inner request",
+			"inner response",
+			"middle response",
+			"outer response",
+		}, calls)
+	})
+
+	t.Run("Successful shared chain across callers", func(t *testing.T) {
+		auth := func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer token")
+				return next(req)
+			}
+		}
+		options := CallerOptions{Middlewares: []Middleware{auth}}
+
+		transport := &recordingTransport{}
+		mockClient := &http.Client{Transport: transport}
+
+		getCaller := NewGetCaller[map[string]interface{}](mockClient, "https://example.com", "test", options)
+		_, err := getCaller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token", transport.request.Header.Get("Authorization"))
+
+		postCaller := NewPostCaller[map[string]interface{}, map[string]interface{}](mockClient, "https://example.com", "test", options)
+		_, err = postCaller.Post(context.Background(), map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token", transport.request.Header.Get("Authorization"))
+		assert.Equal(t, http.MethodPost, transport.request.Method)
+	})
+
+	t.Run("Successful retries pass through middlewares on every attempt", func(t *testing.T) {
+		var statuses []int
+		record := func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				res, err := next(req)
+				if err == nil {
+					statuses = append(statuses, res.StatusCode)
+				}
+				return res, err
+			}
+		}
+
+		transport := &sequenceTransport{statusCodes: []int{503, 200}}
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+				Clock:       newFakeClock(),
+				Middlewares: []Middleware{record},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{503, 200}, statuses)
+	})
+
+	t.Run("Successful short-circuit without calling the client", func(t *testing.T) {
+		cached := func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"test": "cached"}`)),
+					Request:    req,
+				}, nil
+			}
+		}
+
+		transport := &recordingTransport{}
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{Middlewares: []Middleware{cached}},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "cached", res["test"])
+		assert.Nil(t, transport.request)
+	})
+
+	t.Run("Failed request with middleware error", func(t *testing.T) {
+		errDenied := errors.New("denied")
+		deny := func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				return nil, errDenied
+			}
+		}
+
+		transport := &streamTransport{body: "{\"id\":1}\n"}
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+			CallerOptions{Middlewares: []Middleware{deny}},
+		)
+
+		_, err := caller.Stream(context.Background())
+		assert.ErrorIs(t, err, ErrTransport)
+		assert.ErrorIs(t, err, errDenied)
+		assert.Nil(t, transport.request)
+	})
+}
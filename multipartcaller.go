@@ -0,0 +1,144 @@
+package httpcaller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// FilePart is a file streamed as one part of a multipart/form-data body.
+type FilePart struct {
+	FieldName   string
+	FileName    string
+	ContentType string // defaults to application/octet-stream
+	Reader      io.Reader
+}
+
+// MultipartRequest is the body of a multipart upload. Fields are encoded like
+// FormCodec encodes them: url.Values, map[string]string, map[string][]string
+// or a struct with `form` tags. The path, query and header struct tags of
+// Fields are bound as for any other request.
+type MultipartRequest[fields any] struct {
+	Fields fields
+	Files  []FilePart
+
+	// Progress is called with the total number of body bytes sent so far.
+	Progress func(sent int64)
+}
+
+// MultipartCaller uploads multipart/form-data bodies. The body is streamed
+// through an io.Pipe instead of being buffered, so uploads are never retried.
+type MultipartCaller[fields any, response any] struct {
+	caller *Caller[fields, response]
+}
+
+func NewMultipartCaller[fields, response any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *MultipartCaller[fields, response] {
+	return &MultipartCaller[fields, response]{
+		caller: NewCaller[fields, response](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+// Upload sends req as a multipart/form-data POST request.
+func (h *MultipartCaller[fields, response]) Upload(ctx context.Context, req MultipartRequest[fields], optional ...CallOption) (response, error) {
+	result, err := h.UploadWithMeta(ctx, req, optional...)
+	return result.Body, err
+}
+
+// UploadWithMeta is like Upload but also returns the response metadata.
+func (h *MultipartCaller[fields, response]) UploadWithMeta(ctx context.Context, req MultipartRequest[fields], optional ...CallOption) (Result[response], error) {
+	values, err := formValues(h.caller.binding.body(req.Fields))
+	if err != nil {
+		return Result[response]{}, fmt.Errorf("%w: %w", ErrEncode, err)
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	body := &requestBody{
+		contentType: "multipart/form-data; boundary=" + boundary,
+		open: func() io.ReadCloser {
+			pr, pw := io.Pipe()
+			go func() {
+				pw.CloseWithError(writeMultipart(pw, boundary, values, req.Files, req.Progress))
+			}()
+			return pr
+		},
+	}
+
+	return h.caller.execute(ctx, http.MethodPost, &req.Fields, body, optional...)
+}
+
+func writeMultipart(w io.Writer, boundary string, values map[string][]string, files []FilePart, progress func(sent int64)) error {
+	if progress != nil {
+		w = &progressWriter{w: w, progress: progress}
+	}
+
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if err := mw.WriteField(key, value); err != nil {
+				return fmt.Errorf("%w: field %q: %w", ErrEncode, key, err)
+			}
+		}
+	}
+
+	for _, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.FieldName), escapeQuotes(file.FileName)))
+		header.Set("Content-Type", contentType)
+
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return fmt.Errorf("%w: file %q: %w", ErrEncode, file.FileName, err)
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return fmt.Errorf("%w: file %q: %w", ErrEncode, file.FileName, err)
+		}
+	}
+
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// progressWriter reports the running total of bytes written.
+type progressWriter struct {
+	w        io.Writer
+	sent     int64
+	progress func(sent int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent)
+	}
+	return n, err
+}
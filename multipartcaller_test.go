@@ -0,0 +1,139 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type kycFields struct {
+	CustomerID string `path:"customerId" form:"-" json:"-"`
+	DocType    string `form:"docType"`
+	Pages      int    `form:"pages,omitempty"`
+}
+
+func TestMultipartCaller(t *testing.T) {
+	t.Run("Successful upload with fields and streamed files", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/customers/c-1/documents", r.URL.Path)
+
+			reader, err := r.MultipartReader()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var parts []string
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				data, _ := io.ReadAll(part)
+				parts = append(parts, fmt.Sprintf("%s|%s|%s|%s", part.FormName(), part.FileName(), part.Header.Get("Content-Type"), data))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"parts": %q}`, strings.Join(parts, ";"))
+		}))
+		defer server.Close()
+
+		caller := NewMultipartCaller[kycFields, map[string]interface{}](
+			server.Client(),
+			server.URL,
+			"customers/:customerId/documents",
+		)
+
+		var progress []int64
+		req := MultipartRequest[kycFields]{
+			Fields: kycFields{CustomerID: "c-1", DocType: "passport", Pages: 2},
+			Files: []FilePart{
+				{FieldName: "front", FileName: "front.png", ContentType: "image/png", Reader: strings.NewReader("PNGDATA")},
+				{FieldName: "import", FileName: `a "b".csv`, Reader: strings.NewReader("id,name\n1,jane\n")},
+			},
+			Progress: func(sent int64) {
+				progress = append(progress, sent)
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Upload(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t,
+			`docType|||passport;`+
+				`pages|||2;`+
+				`front|front.png|image/png|PNGDATA;`+
+				`import|a "b".csv|application/octet-stream|id,name`+"\n1,jane\n",
+			strings.ReplaceAll(res["parts"].(string), "\r", ""),
+		)
+		assert.NotEmpty(t, progress)
+		for i := 1; i < len(progress); i++ {
+			assert.Greater(t, progress[i], progress[i-1])
+		}
+	})
+
+	t.Run("Successful upload with map fields", func(t *testing.T) {
+		transport := &recordingTransport{}
+		caller := NewMultipartCaller[map[string]string, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"upload",
+		)
+
+		ctx := context.Background()
+		result, err := caller.UploadWithMeta(ctx, MultipartRequest[map[string]string]{
+			Fields: map[string]string{"kind": "csv"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Attempts)
+		assert.True(t, strings.HasPrefix(transport.request.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+		assert.Contains(t, string(transport.body), `name="kind"`)
+	})
+
+	t.Run("Failed upload due to file read error is not retried", func(t *testing.T) {
+		transport := &sequenceTransport{}
+		caller := NewMultipartCaller[map[string]string, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"upload",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts: 3,
+					RetryableError: func(err error) bool {
+						return true
+					},
+				},
+				Clock: newFakeClock(),
+			},
+		)
+
+		ctx := context.Background()
+		_, err := caller.Upload(ctx, MultipartRequest[map[string]string]{
+			Files: []FilePart{{FieldName: "file", FileName: "broken.bin", Reader: &errorReader{}}},
+		})
+		assert.ErrorIs(t, err, ErrEncode)
+		assert.Equal(t, 1, transport.attempts())
+	})
+
+	t.Run("Failed upload due to unsupported fields type", func(t *testing.T) {
+		caller := NewMultipartCaller[int, map[string]interface{}](
+			&http.Client{Transport: &recordingTransport{}},
+			"https://example.com",
+			"upload",
+		)
+
+		ctx := context.Background()
+		_, err := caller.Upload(ctx, MultipartRequest[int]{Fields: 1})
+		assert.True(t, errors.Is(err, ErrEncode))
+	})
+}
@@ -0,0 +1,30 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+)
+
+type PatchCaller[request any, response any] struct {
+	caller *Caller[request, response]
+}
+
+func NewPatchCaller[request, response any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *PatchCaller[request, response] {
+	return &PatchCaller[request, response]{
+		caller: NewCaller[request, response](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+func (h *PatchCaller[request, response]) Patch(ctx context.Context, req request, optional ...CallOption) (response, error) {
+	return h.caller.do(ctx, http.MethodPatch, &req, optional...)
+}
+
+// PatchWithMeta is like Patch but also returns the response metadata.
+func (h *PatchCaller[request, response]) PatchWithMeta(ctx context.Context, req request, optional ...CallOption) (Result[response], error) {
+	return h.caller.doWithMeta(ctx, http.MethodPatch, &req, optional...)
+}
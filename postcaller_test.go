@@ -3,6 +3,7 @@ package httpcaller
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -262,6 +263,66 @@ func TestPostCaller(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unmarshal response error")
 	})
+
+	t.Run("Failed POST request due to non-2xx status", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"code": "E500", "message": "boom"}`,
+				statusCode:       http.StatusInternalServerError,
+			},
+		}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Post(ctx, req)
+		assert.Error(t, err)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+		assert.Equal(t, "POST", httpErr.Method)
+		assert.Equal(t, "https://example.com/test", httpErr.URL)
+		assert.Equal(t, "application/json", httpErr.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"code": "E500", "message": "boom"}`, string(httpErr.Body))
+	})
+
+	t.Run("Successful POST request with custom success status policy", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data"}`,
+				statusCode:       http.StatusNotFound,
+			},
+		}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				IsSuccessStatus: func(statusCode int) bool {
+					return statusCode < 500
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		res, err := caller.Post(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
 }
 
 // Mock transport for simulating different scenarios
@@ -271,6 +332,7 @@ type mockTransport struct {
 	readError            bool
 	unmarshalError       bool
 	mockResponseBody     string
+	statusCode           int
 }
 
 func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -297,8 +359,13 @@ func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 
 	if m.mockResponseBody != "" {
+		statusCode := m.statusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
 		return &http.Response{
-			StatusCode: http.StatusOK,
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
 			Body:       io.NopCloser(bytes.NewBufferString(m.mockResponseBody)),
 		}, nil
 	}
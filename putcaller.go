@@ -0,0 +1,30 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+)
+
+type PutCaller[request any, response any] struct {
+	caller *Caller[request, response]
+}
+
+func NewPutCaller[request, response any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *PutCaller[request, response] {
+	return &PutCaller[request, response]{
+		caller: NewCaller[request, response](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+func (h *PutCaller[request, response]) Put(ctx context.Context, req request, optional ...CallOption) (response, error) {
+	return h.caller.do(ctx, http.MethodPut, &req, optional...)
+}
+
+// PutWithMeta is like Put but also returns the response metadata.
+func (h *PutCaller[request, response]) PutWithMeta(ctx context.Context, req request, optional ...CallOption) (Result[response], error) {
+	return h.caller.doWithMeta(ctx, http.MethodPut, &req, optional...)
+}
@@ -0,0 +1,262 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutCaller(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockTransport{},
+	}
+
+	t.Run("Successful PUT request with default headers", func(t *testing.T) {
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		res, err := caller.Put(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful PUT request with additional headers", func(t *testing.T) {
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		optional := CallOption{
+			Header: map[string]string{
+				"Custom-Header": "custom_value",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Put(ctx, req, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful PUT request with path parameters", func(t *testing.T) {
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test/:id",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		optional := CallOption{
+			PathParam: map[string]string{
+				"id": "123",
+			},
+		}
+
+		ctx := context.Background()
+		res, err := caller.Put(ctx, req, optional)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Successful PUT request with base success response validation", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data", "status": "success"}`,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "success",
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		res, err := caller.Put(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+	})
+
+	t.Run("Failed PUT request due to unmatched base success response", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data", "status": "error"}`,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				DefaultHeaders: map[string]string{
+					"Authorization": "Bearer default_token_here",
+				},
+				BaseSuccessResponse: map[string]interface{}{
+					"status": "success",
+				},
+			},
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsuccessful response for key status")
+	})
+
+	t.Run("Failed PUT request due to network error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				networkError: true,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "put request error")
+	})
+
+	t.Run("Failed PUT request due to JSON marshal error", func(t *testing.T) {
+		caller := NewPutCaller[chan int, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := make(chan int)
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "marshal request error")
+	})
+
+	t.Run("Failed PUT request due to HTTP request creation error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				requestCreationError: true,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "create request error")
+	})
+
+	t.Run("Failed PUT request due to response read error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				readError: true,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "read response error")
+	})
+
+	t.Run("Failed PUT request due to response unmarshal error", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				unmarshalError: true,
+			},
+		}
+
+		caller := NewPutCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		req := map[string]interface{}{
+			"test": "data",
+		}
+
+		ctx := context.Background()
+		_, err := caller.Put(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal response error")
+	})
+}
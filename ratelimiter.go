@@ -0,0 +1,83 @@
+package httpcaller
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiterSettings configures a RateLimiter.
+type RateLimiterSettings struct {
+	// Rate is the number of requests allowed per second. A zero or
+	// negative rate disables limiting.
+	Rate float64
+
+	// Burst is the bucket size, the number of requests that may be sent at
+	// once. Defaults to 1.
+	Burst int
+
+	// Clock defaults to the system clock.
+	Clock Clock
+}
+
+// RateLimiter is a token bucket limiting how often attempts are sent. Share
+// one limiter between callers that target the same host.
+type RateLimiter struct {
+	settings RateLimiterSettings
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(settings RateLimiterSettings) *RateLimiter {
+	if settings.Burst <= 0 {
+		settings.Burst = 1
+	}
+	if settings.Clock == nil {
+		settings.Clock = realClock{}
+	}
+
+	return &RateLimiter{
+		settings: settings,
+		tokens:   float64(settings.Burst),
+		last:     settings.Clock.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.settings.Rate <= 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := l.settings.Clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(float64(l.settings.Burst), l.tokens+elapsed.Seconds()*l.settings.Rate)
+		l.last = now
+	}
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.settings.Rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-l.settings.Clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
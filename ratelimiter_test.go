@@ -0,0 +1,101 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("Allows a burst then spaces out requests", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := NewRateLimiter(RateLimiterSettings{Rate: 2, Burst: 2, Clock: clock})
+
+		ctx := context.Background()
+		assert.NoError(t, limiter.Wait(ctx))
+		assert.NoError(t, limiter.Wait(ctx))
+		assert.Empty(t, clock.waits())
+
+		assert.NoError(t, limiter.Wait(ctx))
+		assert.Equal(t, []time.Duration{500 * time.Millisecond}, clock.waits())
+	})
+
+	t.Run("Refills tokens over time", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := NewRateLimiter(RateLimiterSettings{Rate: 1, Burst: 1, Clock: clock})
+
+		ctx := context.Background()
+		assert.NoError(t, limiter.Wait(ctx))
+
+		clock.After(2 * time.Second)
+		assert.NoError(t, limiter.Wait(ctx))
+		assert.Len(t, clock.waits(), 1)
+	})
+
+	t.Run("Returns when the context is done and gives the token back", func(t *testing.T) {
+		clock := newFakeClock()
+		clock.block = true
+		limiter := NewRateLimiter(RateLimiterSettings{Rate: 1, Burst: 1, Clock: clock})
+
+		assert.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		clock.onAfter = cancel
+		assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+
+		clock.onAfter = nil
+		clock.block = false
+		assert.NoError(t, limiter.Wait(context.Background()))
+		assert.Equal(t, []time.Duration{time.Second}, clock.waits())
+	})
+
+	t.Run("Is shared between callers", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := NewRateLimiter(RateLimiterSettings{Rate: 10, Burst: 1, Clock: clock})
+		options := CallerOptions{RateLimiter: limiter}
+
+		getCaller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"https://example.com",
+			"test",
+			options,
+		)
+		postCaller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"https://example.com",
+			"test",
+			options,
+		)
+
+		ctx := context.Background()
+		_, err := getCaller.Get(ctx)
+		assert.NoError(t, err)
+		_, err = postCaller.Post(ctx, map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond}, clock.waits())
+	})
+
+	t.Run("Fails the call when the context is done while waiting", func(t *testing.T) {
+		clock := newFakeClock()
+		clock.block = true
+		limiter := NewRateLimiter(RateLimiterSettings{Rate: 1, Burst: 1, Clock: clock})
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{}},
+			"https://example.com",
+			"test",
+			CallerOptions{RateLimiter: limiter},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		clock.onAfter = cancel
+		_, err = caller.Get(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,24 @@
+package httpcaller
+
+import (
+	"net/http"
+	"time"
+)
+
+// Result is a decoded response body together with the response metadata. On
+// error the fields that were known when the call failed are still filled in.
+type Result[response any] struct {
+	Body       response
+	StatusCode int
+	Header     http.Header
+
+	// URL is the final request URL, after any redirects.
+	URL string
+
+	// Attempts is the number of attempts sent, including retries.
+	Attempts int
+
+	// Duration is the total time spent on the call, including waits between
+	// attempts.
+	Duration time.Duration
+}
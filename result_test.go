@@ -0,0 +1,102 @@
+package httpcaller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	t.Run("Successful POST request returns status and headers", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data"}`,
+				statusCode:       http.StatusCreated,
+			},
+		}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		result, err := caller.PostWithMeta(ctx, map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		assert.Equal(t, "data", result.Body["test"])
+		assert.Equal(t, http.StatusCreated, result.StatusCode)
+		assert.Equal(t, "application/json", result.Header.Get("Content-Type"))
+		assert.Equal(t, "https://example.com/test", result.URL)
+		assert.Equal(t, 1, result.Attempts)
+	})
+
+	t.Run("Successful GET request reports attempts, duration and final URL", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/old" {
+				http.Redirect(w, r, "/new", http.StatusFound)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(w, `{"test": "data"}`)
+		}))
+		defer server.Close()
+
+		caller := NewGetCaller[map[string]interface{}](
+			server.Client(),
+			server.URL,
+			"old",
+		)
+
+		ctx := context.Background()
+		result, err := caller.GetWithMeta(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/new", result.URL)
+		assert.Equal(t, `"v1"`, result.Header.Get("ETag"))
+		assert.Equal(t, 1, result.Attempts)
+	})
+
+	t.Run("Failed GET request keeps metadata of the last attempt", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{503, 503}}
+		clock := newFakeClock()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Second},
+				Clock:       clock,
+			},
+		)
+
+		ctx := context.Background()
+		result, err := caller.GetWithMeta(ctx)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		assert.Equal(t, 2, result.Attempts)
+		assert.Equal(t, clock.waits()[0], result.Duration)
+	})
+
+	t.Run("Successful DELETE requests return metadata", func(t *testing.T) {
+		caller := NewDeleteCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: &mockTransport{mockResponseBody: `{"test": "data"}`, statusCode: http.StatusAccepted}},
+			"https://example.com",
+			"test",
+		)
+
+		ctx := context.Background()
+		result, err := caller.DeleteWithMeta(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, result.StatusCode)
+
+		result, err = caller.DeleteWithBodyWithMeta(ctx, map[string]interface{}{"reason": "duplicate"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, result.StatusCode)
+	})
+}
@@ -0,0 +1,116 @@
+package httpcaller
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay  = 10 * time.Second
+)
+
+var defaultRetryableStatusCodes = []int{429, 502, 503, 504}
+
+// RetryPolicy controls how failed attempts are retried. Request bodies are
+// buffered, so every attempt resends the same body. Streamed bodies, such as
+// multipart uploads, are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay and MaxDelay bound the full-jitter exponential backoff: the
+	// wait before retry n is a random duration in
+	// [0, min(MaxDelay, BaseDelay*2^(n-1))]. They default to 100ms and 10s.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// RetryableStatusCodes lists the response status codes that are retried.
+	// Defaults to 429, 502, 503 and 504.
+	RetryableStatusCodes []int
+
+	// RetryableError reports whether an error that is not an *HTTPError is
+	// retried. Defaults to retrying transport and read errors.
+	RetryableError func(err error) bool
+
+	// MaxRetryAfter caps the wait requested by a Retry-After response header,
+	// which replaces the backoff delay when present. Defaults to one minute.
+	MaxRetryAfter time.Duration
+
+	// PerAttemptTimeout bounds each attempt, including reading the body.
+	// Zero means attempts are only bounded by the call's context.
+	PerAttemptTimeout time.Duration
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) perAttemptTimeout() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return p.PerAttemptTimeout
+}
+
+func (p *RetryPolicy) shouldRetry(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		statusCodes := p.RetryableStatusCodes
+		if statusCodes == nil {
+			statusCodes = defaultRetryableStatusCodes
+		}
+		return slices.Contains(statusCodes, httpErr.StatusCode)
+	}
+
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return errors.Is(err, ErrTransport) || errors.Is(err, ErrReadBody)
+}
+
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p == nil || p.MaxRetryAfter <= 0 {
+		return defaultMaxRetryAfter
+	}
+	return p.MaxRetryAfter
+}
+
+// retryDelay returns the wait before retrying err, preferring the server's
+// Retry-After header over the backoff delay.
+func (p *RetryPolicy) retryDelay(attempt int, err error, now time.Time) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		if delay, ok := parseRetryAfter(httpErr.Header, now); ok {
+			return min(delay, p.maxRetryAfter())
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// backoff returns the wait before the retry that follows the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	baseDelay := p.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	delay := maxDelay
+	if shift := attempt - 1; shift < 62 && baseDelay <= maxDelay>>shift {
+		delay = baseDelay << shift
+	}
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
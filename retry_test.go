@@ -0,0 +1,294 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("Successful GET request after retryable statuses", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{503, 502, 200}}
+		clock := newFakeClock()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts: 3,
+					BaseDelay:   100 * time.Millisecond,
+					MaxDelay:    time.Second,
+				},
+				Clock: clock,
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, 3, transport.attempts())
+
+		waits := clock.waits()
+		assert.Len(t, waits, 2)
+		assert.LessOrEqual(t, waits[0], 100*time.Millisecond)
+		assert.LessOrEqual(t, waits[1], 200*time.Millisecond)
+	})
+
+	t.Run("Failed GET request after exhausting attempts", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{503, 503, 503, 200}}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+				Clock:       newFakeClock(),
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+		assert.Equal(t, 3, transport.attempts())
+	})
+
+	t.Run("Failed GET request with non-retryable status", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{400, 200}}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+				Clock:       newFakeClock(),
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Equal(t, 1, transport.attempts())
+	})
+
+	t.Run("Successful POST request resends body after transport error", func(t *testing.T) {
+		transport := &sequenceTransport{errs: []error{errors.New("connection reset by peer")}, statusCodes: []int{0, 200}}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+				Clock:       newFakeClock(),
+			},
+		)
+
+		res, err := caller.Post(context.Background(), map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, 2, transport.attempts())
+		assert.Len(t, transport.bodies, 2)
+		assert.JSONEq(t, `{"test": "data"}`, transport.bodies[0])
+		assert.Equal(t, transport.bodies[0], transport.bodies[1])
+	})
+
+	t.Run("Failed GET request when retryable error predicate rejects error", func(t *testing.T) {
+		transport := &sequenceTransport{errs: []error{errors.New("certificate expired")}, statusCodes: []int{0, 200}}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts: 3,
+					RetryableError: func(err error) bool {
+						return false
+					},
+				},
+				Clock: newFakeClock(),
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrTransport)
+		assert.Equal(t, 1, transport.attempts())
+	})
+
+	t.Run("Failed GET request when context is cancelled between attempts", func(t *testing.T) {
+		transport := &sequenceTransport{statusCodes: []int{503, 200}}
+		clock := newFakeClock()
+		clock.block = true
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+				Clock:       clock,
+			},
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		clock.onAfter = cancel
+
+		_, err := caller.Get(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Equal(t, 1, transport.attempts())
+	})
+
+	t.Run("Successful GET request after per-attempt timeout", func(t *testing.T) {
+		attempts := 0
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts == 1 {
+					<-req.Context().Done()
+					return nil, req.Context().Err()
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"test": "data"}`)),
+				}, nil
+			}),
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{
+					MaxAttempts:       2,
+					PerAttemptTimeout: time.Millisecond,
+				},
+				Clock: newFakeClock(),
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "data", res["test"])
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Backoff stays within the exponential cap", func(t *testing.T) {
+		policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+		for i := 0; i < 100; i++ {
+			assert.LessOrEqual(t, policy.backoff(1), 100*time.Millisecond)
+			assert.LessOrEqual(t, policy.backoff(2), 200*time.Millisecond)
+			assert.LessOrEqual(t, policy.backoff(3), 300*time.Millisecond)
+			assert.LessOrEqual(t, policy.backoff(80), 300*time.Millisecond)
+			assert.GreaterOrEqual(t, policy.backoff(80), time.Duration(0))
+		}
+	})
+}
+
+// Fake clock that records requested waits and fires immediately unless blocked
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	sleeps  []time.Duration
+	block   bool
+	onAfter func()
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	now, block, onAfter := c.now, c.block, c.onAfter
+	c.mu.Unlock()
+
+	if onAfter != nil {
+		onAfter()
+	}
+
+	ch := make(chan time.Time, 1)
+	if !block {
+		ch <- now
+	}
+	return ch
+}
+
+func (c *fakeClock) waits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// Sequence transport that replies with a scripted status code or error per attempt
+type sequenceTransport struct {
+	mu          sync.Mutex
+	statusCodes []int
+	errs        []error
+	headers     []http.Header
+	bodies      []string
+	count       int
+}
+
+func (s *sequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.count
+	s.count++
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		s.bodies = append(s.bodies, string(body))
+	}
+
+	if i < len(s.errs) && s.errs[i] != nil {
+		return nil, s.errs[i]
+	}
+
+	statusCode := http.StatusOK
+	if i < len(s.statusCodes) {
+		statusCode = s.statusCodes[i]
+	}
+	header := http.Header{}
+	if i < len(s.headers) && s.headers[i] != nil {
+		header = s.headers[i]
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"test": "data", "attempt": %d}`, i+1))),
+		Request:    req,
+	}, nil
+}
+
+func (s *sequenceTransport) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
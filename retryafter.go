@@ -0,0 +1,139 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultMaxRetryAfter = time.Minute
+
+// epochThreshold separates X-RateLimit-Reset values sent as Unix timestamps
+// from values sent as a number of seconds.
+const epochThreshold = 1_000_000_000
+
+// parseRetryAfter reads a Retry-After header given either as delay seconds or
+// as an HTTP-date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset returns how long to hold off when the response reports
+// an exhausted rate-limit quota. It understands X-RateLimit-Remaining and
+// X-RateLimit-Reset, the IETF RateLimit-Remaining and RateLimit-Reset fields,
+// and the combined IETF RateLimit field.
+func parseRateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining != "" {
+		if isExhausted(remaining) {
+			return parseResetValue(header.Get("X-RateLimit-Reset"), now)
+		}
+		return 0, false
+	}
+
+	if remaining := header.Get("RateLimit-Remaining"); remaining != "" {
+		if isExhausted(remaining) {
+			return parseResetValue(header.Get("RateLimit-Reset"), now)
+		}
+		return 0, false
+	}
+
+	if field := header.Get("RateLimit"); field != "" {
+		var remaining, reset string
+		for _, part := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' || r == ';' }) {
+			key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok {
+				continue
+			}
+			switch strings.ToLower(key) {
+			case "remaining", "r":
+				remaining = value
+			case "reset", "t":
+				reset = value
+			}
+		}
+		if remaining != "" && isExhausted(remaining) {
+			return parseResetValue(reset, now)
+		}
+	}
+
+	return 0, false
+}
+
+func isExhausted(remaining string) bool {
+	n, err := strconv.ParseFloat(strings.TrimSpace(remaining), 64)
+	return err == nil && n <= 0
+}
+
+func parseResetValue(value string, now time.Time) (time.Duration, bool) {
+	reset, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+	if reset >= epochThreshold {
+		delay := time.Unix(0, int64(reset*float64(time.Second))).Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return time.Duration(reset * float64(time.Second)), true
+}
+
+// rateLimitGate holds requests back until an exhausted rate-limit quota
+// resets.
+type rateLimitGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (g *rateLimitGate) observe(header http.Header, now time.Time, maxWait time.Duration) {
+	delay, ok := parseRateLimitReset(header, now)
+	if !ok || delay <= 0 {
+		return
+	}
+	if delay > maxWait {
+		delay = maxWait
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := now.Add(delay); until.After(g.until) {
+		g.until = until
+	}
+}
+
+func (g *rateLimitGate) wait(ctx context.Context, clock Clock) error {
+	g.mu.Lock()
+	delay := g.until.Sub(clock.Now())
+	g.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,174 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Parses Retry-After header", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			value  string
+			delay  time.Duration
+			exists bool
+		}{
+			{name: "seconds", value: "120", delay: 2 * time.Minute, exists: true},
+			{name: "HTTP-date", value: now.Add(30 * time.Second).Format(http.TimeFormat), delay: 30 * time.Second, exists: true},
+			{name: "HTTP-date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), delay: 0, exists: true},
+			{name: "negative seconds", value: "-1", exists: false},
+			{name: "invalid", value: "soon", exists: false},
+			{name: "missing", value: "", exists: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				header := http.Header{}
+				if tt.value != "" {
+					header.Set("Retry-After", tt.value)
+				}
+				delay, ok := parseRetryAfter(header, now)
+				assert.Equal(t, tt.exists, ok)
+				assert.Equal(t, tt.delay, delay)
+			})
+		}
+	})
+
+	t.Run("Parses rate-limit reset headers", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			header http.Header
+			delay  time.Duration
+			exists bool
+		}{
+			{
+				name:   "X-RateLimit with seconds",
+				header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"15"}},
+				delay:  15 * time.Second,
+				exists: true,
+			},
+			{
+				name:   "X-RateLimit with Unix timestamp",
+				header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Minute).Unix(), 10)}},
+				delay:  time.Minute,
+				exists: true,
+			},
+			{
+				name:   "X-RateLimit with quota left",
+				header: http.Header{"X-Ratelimit-Remaining": {"10"}, "X-Ratelimit-Reset": {"15"}},
+				exists: false,
+			},
+			{
+				name:   "IETF RateLimit fields",
+				header: http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"7"}},
+				delay:  7 * time.Second,
+				exists: true,
+			},
+			{
+				name:   "IETF combined RateLimit field",
+				header: http.Header{"Ratelimit": {"limit=100, remaining=0, reset=3"}},
+				delay:  3 * time.Second,
+				exists: true,
+			},
+			{
+				name:   "IETF structured RateLimit field",
+				header: http.Header{"Ratelimit": {`"default";r=0;t=4`}},
+				delay:  4 * time.Second,
+				exists: true,
+			},
+			{
+				name:   "No rate-limit headers",
+				header: http.Header{},
+				exists: false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				delay, ok := parseRateLimitReset(tt.header, now)
+				assert.Equal(t, tt.exists, ok)
+				assert.Equal(t, tt.delay, delay)
+			})
+		}
+	})
+
+	t.Run("Waits for Retry-After before retrying", func(t *testing.T) {
+		transport := &sequenceTransport{
+			statusCodes: []int{429, 200},
+			headers:     []http.Header{{"Retry-After": {"3"}}},
+		}
+		clock := newFakeClock()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2, MaxDelay: time.Millisecond},
+				Clock:       clock,
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{3 * time.Second}, clock.waits())
+	})
+
+	t.Run("Caps Retry-After at MaxRetryAfter", func(t *testing.T) {
+		transport := &sequenceTransport{
+			statusCodes: []int{503, 200},
+			headers:     []http.Header{{"Retry-After": {"3600"}}},
+		}
+		clock := newFakeClock()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2, MaxRetryAfter: 10 * time.Second},
+				Clock:       clock,
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{10 * time.Second}, clock.waits())
+	})
+
+	t.Run("Holds requests back while the quota is exhausted", func(t *testing.T) {
+		transport := &sequenceTransport{
+			headers: []http.Header{{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"5"}}},
+		}
+		clock := newFakeClock()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				RateLimitHeaders: true,
+				Clock:            clock,
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, clock.waits())
+
+		_, err = caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{5 * time.Second}, clock.waits())
+
+		_, err = caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, clock.waits(), 1)
+	})
+}
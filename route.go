@@ -0,0 +1,139 @@
+package httpcaller
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// route is an endpoint template parsed once when a caller is created. It
+// supports ":name" parameters at the start of a path segment and "{name}"
+// parameters anywhere in the path. The base URL is never templated.
+type route struct {
+	template string
+	parts    []routePart
+	params   map[string]bool
+	err      error
+}
+
+type routePart struct {
+	literal string
+	param   string
+}
+
+func parseRoute(baseURL string, endpoint string) *route {
+	template := joinURL(baseURL, endpoint)
+	r := &route{template: template, params: make(map[string]bool)}
+
+	path, rest := endpoint, ""
+	if i := strings.IndexAny(endpoint, "?#"); i >= 0 {
+		path, rest = endpoint[:i], endpoint[i:]
+	}
+	path = strings.TrimLeft(path, "/")
+
+	prefix := strings.TrimRight(baseURL, "/")
+	if path != "" || rest == "" {
+		prefix += "/"
+	}
+	r.addLiteral(prefix)
+
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == ':' && (i == 0 || path[i-1] == '/') && i+1 < len(path) && isParamChar(path[i+1]):
+			end := i + 1
+			for end < len(path) && isParamChar(path[end]) {
+				end++
+			}
+			r.addParam(path[i+1 : end])
+			i = end
+		case path[i] == '{':
+			end := strings.IndexByte(path[i:], '}')
+			if end < 0 {
+				r.err = fmt.Errorf("%w: unclosed \"{\" in endpoint %q", ErrPathParam, endpoint)
+				return r
+			}
+			name := path[i+1 : i+end]
+			if name == "" || strings.IndexFunc(name, func(c rune) bool { return c > 127 || !isParamChar(byte(c)) }) >= 0 {
+				r.err = fmt.Errorf("%w: invalid parameter name %q in endpoint %q", ErrPathParam, name, endpoint)
+				return r
+			}
+			r.addParam(name)
+			i += end + 1
+		default:
+			end := i + 1
+			for end < len(path) && path[end] != ':' && path[end] != '{' {
+				end++
+			}
+			r.addLiteral(path[i:end])
+			i = end
+		}
+	}
+	r.addLiteral(rest)
+
+	return r
+}
+
+func (r *route) addLiteral(literal string) {
+	if literal == "" {
+		return
+	}
+	if n := len(r.parts); n > 0 && r.parts[n-1].param == "" {
+		r.parts[n-1].literal += literal
+		return
+	}
+	r.parts = append(r.parts, routePart{literal: literal})
+}
+
+func (r *route) addParam(name string) {
+	r.params[name] = true
+	r.parts = append(r.parts, routePart{param: name})
+}
+
+// expand fills in the path parameters, percent-escaping their values.
+func (r *route) expand(pathParams map[string]string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	var unknown []string
+	for key := range pathParams {
+		if !r.params[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("%w: unknown parameters %q for endpoint %q", ErrPathParam, unknown, r.template)
+	}
+
+	var sb strings.Builder
+	for _, part := range r.parts {
+		if part.param == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+		value := pathParams[part.param]
+		if value == "" {
+			return "", fmt.Errorf("%w: missing parameter %q for endpoint %q", ErrPathParam, part.param, r.template)
+		}
+		sb.WriteString(url.PathEscape(value))
+	}
+	return sb.String(), nil
+}
+
+// joinURL joins baseURL and endpoint with exactly one slash between them.
+func joinURL(baseURL string, endpoint string) string {
+	endpoint = strings.TrimLeft(endpoint, "/")
+	if endpoint == "" {
+		return strings.TrimRight(baseURL, "/") + "/"
+	}
+	if endpoint[0] == '?' || endpoint[0] == '#' {
+		return strings.TrimRight(baseURL, "/") + endpoint
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + endpoint
+}
+
+func isParamChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,144 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoute(t *testing.T) {
+	t.Run("Expands path parameters", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			baseURL    string
+			endpoint   string
+			pathParams map[string]string
+			expected   string
+		}{
+			{
+				name:       "colon parameter",
+				baseURL:    "https://example.com",
+				endpoint:   "users/:id",
+				pathParams: map[string]string{"id": "123"},
+				expected:   "https://example.com/users/123",
+			},
+			{
+				name:       "brace parameter",
+				baseURL:    "https://example.com",
+				endpoint:   "users/{id}/orders/{orderId}.json",
+				pathParams: map[string]string{"id": "1", "orderId": "2"},
+				expected:   "https://example.com/users/1/orders/2.json",
+			},
+			{
+				name:       "parameter names sharing a prefix",
+				baseURL:    "https://example.com",
+				endpoint:   "ids/:id/types/:idType",
+				pathParams: map[string]string{"id": "1", "idType": "passport"},
+				expected:   "https://example.com/ids/1/types/passport",
+			},
+			{
+				name:       "escapes values",
+				baseURL:    "https://example.com",
+				endpoint:   "files/:name",
+				pathParams: map[string]string{"name": "a/b?c d"},
+				expected:   "https://example.com/files/a%2Fb%3Fc%20d",
+			},
+			{
+				name:       "does not template base URL port",
+				baseURL:    "http://localhost:8080/",
+				endpoint:   "/ports/:port",
+				pathParams: map[string]string{"port": "443"},
+				expected:   "http://localhost:8080/ports/443",
+			},
+			{
+				name:       "keeps colon inside a segment",
+				baseURL:    "https://example.com",
+				endpoint:   "v1/items:batchGet",
+				pathParams: nil,
+				expected:   "https://example.com/v1/items:batchGet",
+			},
+			{
+				name:       "keeps query in endpoint",
+				baseURL:    "https://example.com/api/",
+				endpoint:   "users/:id?expand=true",
+				pathParams: map[string]string{"id": "7"},
+				expected:   "https://example.com/api/users/7?expand=true",
+			},
+			{
+				name:     "empty endpoint",
+				baseURL:  "https://example.com",
+				endpoint: "",
+				expected: "https://example.com/",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				url, err := parseRoute(tt.baseURL, tt.endpoint).expand(tt.pathParams)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, url)
+			})
+		}
+	})
+
+	t.Run("Rejects invalid templates and parameters", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			endpoint   string
+			pathParams map[string]string
+			message    string
+		}{
+			{
+				name:     "missing parameter",
+				endpoint: "users/:id",
+				message:  `missing parameter "id"`,
+			},
+			{
+				name:       "empty parameter",
+				endpoint:   "users/{id}",
+				pathParams: map[string]string{"id": ""},
+				message:    `missing parameter "id"`,
+			},
+			{
+				name:       "unknown parameter",
+				endpoint:   "users/:id",
+				pathParams: map[string]string{"id": "1", "idType": "x"},
+				message:    `unknown parameters ["idType"]`,
+			},
+			{
+				name:     "unclosed brace",
+				endpoint: "users/{id",
+				message:  `unclosed "{"`,
+			},
+			{
+				name:     "invalid brace name",
+				endpoint: "users/{user id}",
+				message:  `invalid parameter name "user id"`,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := parseRoute("https://example.com", tt.endpoint).expand(tt.pathParams)
+				assert.ErrorIs(t, err, ErrPathParam)
+				assert.Contains(t, err.Error(), tt.message)
+			})
+		}
+	})
+
+	t.Run("Failed GET request due to missing path parameter", func(t *testing.T) {
+		transport := &sequenceTransport{}
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test/:id",
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrCreateRequest)
+		assert.ErrorIs(t, err, ErrPathParam)
+		assert.Equal(t, 0, transport.attempts())
+	})
+}
@@ -0,0 +1,300 @@
+package httpcaller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSSERetry is the reconnection delay used until the server sends a
+// retry field.
+const defaultSSERetry = 3 * time.Second
+
+// maxSSEReconnects bounds consecutive reconnections that deliver no event.
+const maxSSEReconnects = 5
+
+// ServerSentEvent is one event of a text/event-stream response.
+type ServerSentEvent[data any] struct {
+	// ID is the last event ID seen on the stream, which is sent back as
+	// Last-Event-ID when reconnecting.
+	ID string
+
+	// Event is the event type. It is empty for the default "message" type.
+	Event string
+
+	// Data is RawData decoded as JSON, or RawData itself when data is a
+	// string.
+	Data    data
+	RawData string
+
+	// Retry is the reconnection delay carried by this event, if any.
+	Retry time.Duration
+}
+
+// SSECaller consumes Server-Sent Events (text/event-stream) responses. When
+// the connection drops, it reconnects after the server's retry delay and
+// sends the last event ID as Last-Event-ID. A stream that ends cleanly is
+// only resumed when the server has sent an event ID, so one-shot streams
+// without IDs are not replayed. A 204 No Content response ends the stream.
+type SSECaller[event any] struct {
+	caller *Caller[any, event]
+}
+
+func NewSSECaller[event any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *SSECaller[event] {
+	return &SSECaller[event]{
+		caller: NewCaller[any, event](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+// Get subscribes with a GET request. The caller must Close the stream;
+// cancelling ctx also ends it.
+func (h *SSECaller[event]) Get(ctx context.Context, optional ...CallOption) (*EventStream[event], error) {
+	return h.subscribe(ctx, http.MethodGet, nil, optional...)
+}
+
+// Post subscribes with a POST request whose body is req encoded with the
+// request codec. The body is resent on every reconnection.
+func (h *SSECaller[event]) Post(ctx context.Context, req any, optional ...CallOption) (*EventStream[event], error) {
+	var reqBody bytes.Buffer
+	if err := h.caller.requestCodec.Encode(&reqBody, req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEncode, err)
+	}
+	return h.subscribe(ctx, http.MethodPost, reqBody.Bytes(), optional...)
+}
+
+func (h *SSECaller[event]) subscribe(ctx context.Context, method string, body []byte, optional ...CallOption) (*EventStream[event], error) {
+	requestURL, headers, err := h.caller.prepare(nil, optional...)
+	if err != nil {
+		return nil, err
+	}
+
+	header := streamHeaders(headers)
+	if header.Get("Accept") == "" {
+		header.Set("Accept", "text/event-stream")
+	}
+	header.Set("Cache-Control", "no-cache")
+	if body != nil && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", h.caller.requestCodec.ContentType())
+	}
+
+	stream := &EventStream[event]{
+		ctx:        ctx,
+		caller:     h.caller,
+		method:     method,
+		requestURL: requestURL,
+		header:     header,
+		body:       body,
+		retry:      defaultSSERetry,
+	}
+	if err := stream.connect(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// EventStream is a cursor over a Server-Sent Events response:
+//
+//	for stream.Next() {
+//		use(stream.Event())
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type EventStream[event any] struct {
+	ctx        context.Context
+	caller     *Caller[any, event]
+	method     string
+	requestURL string
+	header     http.Header
+	body       []byte
+
+	response io.ReadCloser
+	reader   *bufio.Reader
+
+	lastEventID string
+	retry       time.Duration
+	reconnects  int
+
+	event ServerSentEvent[event]
+	err   error
+	done  bool
+}
+
+// Next reads and decodes the next event, reconnecting if the connection
+// drops. It returns false at the end of the stream or on the first error,
+// which is then reported by Err. When the data of an event cannot be
+// decoded, Event still returns it with RawData set.
+func (s *EventStream[event]) Next() bool {
+	if s.done {
+		return false
+	}
+
+	for {
+		next, err := s.readEvent()
+		if err == nil {
+			s.reconnects = 0
+			s.event = next
+			if p, ok := any(&s.event.Data).(*string); ok {
+				*p = next.RawData
+			} else if decodeErr := json.Unmarshal([]byte(next.RawData), &s.event.Data); decodeErr != nil {
+				s.fail(fmt.Errorf("%w: %w", ErrDecode, decodeErr))
+				if s.caller.onDecodeError != nil {
+					s.caller.onDecodeError([]byte(next.RawData), s.err)
+				}
+				return false
+			}
+			return true
+		}
+
+		if s.ctx.Err() != nil {
+			s.fail(fmt.Errorf("%w: %w", ErrReadBody, s.ctx.Err()))
+			return false
+		}
+		if errors.Is(err, io.EOF) && s.lastEventID == "" {
+			s.fail(nil)
+			return false
+		}
+		if s.reconnects >= maxSSEReconnects {
+			s.fail(fmt.Errorf("%w: %w", ErrReadBody, err))
+			return false
+		}
+		if err := s.reconnect(); err != nil {
+			s.fail(err)
+			return false
+		}
+		if s.done {
+			return false
+		}
+	}
+}
+
+// Event returns the event read by the last call to Next.
+func (s *EventStream[event]) Event() ServerSentEvent[event] {
+	return s.event
+}
+
+// LastEventID returns the last event ID received on the stream.
+func (s *EventStream[event]) LastEventID() string {
+	return s.lastEventID
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *EventStream[event]) Err() error {
+	return s.err
+}
+
+// Close releases the connection. It is safe to call more than once.
+func (s *EventStream[event]) Close() error {
+	s.done = true
+	if s.response == nil {
+		return nil
+	}
+	response := s.response
+	s.response = nil
+	return response.Close()
+}
+
+func (s *EventStream[event]) fail(err error) {
+	s.err = err
+	s.Close()
+}
+
+func (s *EventStream[event]) connect() error {
+	header := s.header.Clone()
+	if s.lastEventID != "" {
+		header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	serverResponse, err := s.caller.openStream(s.ctx, s.method, s.requestURL, header, s.body, nil)
+	if err != nil {
+		return err
+	}
+	s.response = serverResponse.Body
+	s.reader = bufio.NewReader(serverResponse.Body)
+	if serverResponse.StatusCode == http.StatusNoContent {
+		s.Close()
+	}
+	return nil
+}
+
+func (s *EventStream[event]) reconnect() error {
+	s.reconnects++
+	s.response.Close()
+	s.response = nil
+
+	select {
+	case <-s.caller.clock.After(s.retry):
+	case <-s.ctx.Done():
+		return fmt.Errorf("reconnect aborted: %w", s.ctx.Err())
+	}
+	return s.connect()
+}
+
+// readEvent parses lines up to the next blank line. Blocks without data are
+// skipped, as are incomplete blocks cut off by the end of the response. An id
+// field only becomes the last event ID once its block is complete, so an
+// event cut off mid-block is requested again on reconnection.
+func (s *EventStream[event]) readEvent() (ServerSentEvent[event], error) {
+	var next ServerSentEvent[event]
+	var data strings.Builder
+	var hasData bool
+	var id string
+	var hasID bool
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return next, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if hasID {
+				s.lastEventID = id
+				hasID = false
+			}
+			if !hasData {
+				next = ServerSentEvent[event]{}
+				continue
+			}
+			next.ID = s.lastEventID
+			next.RawData = data.String()
+			return next, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			// Comment line, often sent as a keep-alive.
+		case "event":
+			next.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				id, hasID = value, true
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
+				next.Retry = s.retry
+			}
+		}
+	}
+}
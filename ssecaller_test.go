@@ -0,0 +1,260 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type chatDelta struct {
+	Content string `json:"content"`
+}
+
+func TestSSECaller(t *testing.T) {
+	t.Run("Successful GET stream parses event fields", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{{
+			body: ": keep-alive\n\n" +
+				"event: delta\nid: 1\ndata: {\"content\":\"Hel\"}\n\n" +
+				"data: {\"content\":\r\ndata: \"lo\"}\r\nretry: 1500\r\n\r\n" +
+				"event: ignored-without-data\n\n" +
+				"data: {\"content\":\"cut off\"}",
+		}, {
+			statusCode: http.StatusNoContent,
+		}}}
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"chat/:id/events",
+			CallerOptions{Clock: newFakeClock()},
+		)
+
+		stream, err := caller.Get(context.Background(), CallOption{PathParam: map[string]string{"id": "42"}})
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var events []ServerSentEvent[chatDelta]
+		for stream.Next() {
+			events = append(events, stream.Event())
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []ServerSentEvent[chatDelta]{
+			{ID: "1", Event: "delta", Data: chatDelta{"Hel"}, RawData: `{"content":"Hel"}`},
+			{ID: "1", Data: chatDelta{"lo"}, RawData: "{\"content\":\n\"lo\"}", Retry: 1500 * time.Millisecond},
+		}, events)
+
+		request := transport.requests[0]
+		assert.Equal(t, "https://example.com/chat/42/events", request.URL.String())
+		assert.Equal(t, "text/event-stream", request.Header.Get("Accept"))
+		assert.Len(t, transport.requests, 2)
+		assert.Equal(t, "1", transport.requests[1].Header.Get("Last-Event-ID"))
+	})
+
+	t.Run("Successful POST stream with string data", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{{
+			body: "data: hello\n\ndata: [DONE]\n\n",
+		}}}
+
+		caller := NewSSECaller[string](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"completions",
+		)
+
+		stream, err := caller.Post(context.Background(), map[string]interface{}{"prompt": "hi"})
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var data []string
+		for stream.Next() {
+			data = append(data, stream.Event().Data)
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []string{"hello", "[DONE]"}, data)
+		assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+		assert.Equal(t, "application/json", transport.requests[0].Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"prompt": "hi"}`, transport.bodies[0])
+	})
+
+	t.Run("Successful reconnect with Last-Event-ID after disconnect", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{
+			{body: "retry: 250\nid: 7\ndata: {\"content\":\"a\"}\n\ndata: {\"content\":\"lost", err: io.ErrUnexpectedEOF},
+			{body: "id: 8\ndata: {\"content\":\"b\"}\n\n"},
+			{statusCode: http.StatusNoContent},
+		}}
+		clock := newFakeClock()
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+			CallerOptions{Clock: clock},
+		)
+
+		stream, err := caller.Post(context.Background(), map[string]interface{}{"topic": "news"})
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var contents []string
+		for stream.Next() {
+			contents = append(contents, stream.Event().Data.Content)
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []string{"a", "b"}, contents)
+		assert.Equal(t, "8", stream.LastEventID())
+
+		assert.Len(t, transport.requests, 3)
+		assert.Empty(t, transport.requests[0].Header.Get("Last-Event-ID"))
+		assert.Equal(t, "7", transport.requests[1].Header.Get("Last-Event-ID"))
+		assert.Equal(t, "8", transport.requests[2].Header.Get("Last-Event-ID"))
+		assert.JSONEq(t, `{"topic": "news"}`, transport.bodies[1])
+		assert.Equal(t, []time.Duration{250 * time.Millisecond, 250 * time.Millisecond}, clock.waits())
+	})
+
+	t.Run("Successful reconnect resends the last dispatched ID after a cut-off block", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{
+			{body: "id: 1\ndata: {\"content\":\"a\"}\n\nid: 2\ndata: {\"content\":\"b\"}\n"},
+			{body: "id: 2\ndata: {\"content\":\"b\"}\n\n"},
+			{statusCode: http.StatusNoContent},
+		}}
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+			CallerOptions{Clock: newFakeClock()},
+		)
+
+		stream, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var ids, contents []string
+		for stream.Next() {
+			ids = append(ids, stream.Event().ID)
+			contents = append(contents, stream.Event().Data.Content)
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []string{"1", "2"}, ids)
+		assert.Equal(t, []string{"a", "b"}, contents)
+
+		assert.Len(t, transport.requests, 3)
+		assert.Equal(t, "1", transport.requests[1].Header.Get("Last-Event-ID"))
+		assert.Equal(t, "2", transport.requests[2].Header.Get("Last-Event-ID"))
+	})
+
+	t.Run("Failed stream after repeated reconnects without events", func(t *testing.T) {
+		connections := make([]sseConnection, maxSSEReconnects+2)
+		for i := range connections {
+			connections[i] = sseConnection{body: "id: 1\n", err: io.ErrUnexpectedEOF}
+		}
+		transport := &sseTransport{connections: connections}
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+			CallerOptions{Clock: newFakeClock()},
+		)
+
+		stream, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+
+		assert.False(t, stream.Next())
+		assert.ErrorIs(t, stream.Err(), ErrReadBody)
+		assert.ErrorIs(t, stream.Err(), io.ErrUnexpectedEOF)
+		assert.Len(t, transport.requests, maxSSEReconnects+1)
+	})
+
+	t.Run("Failed stream due to undecodable data", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{{body: "data: [DONE]\n\n"}}}
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+
+		assert.False(t, stream.Next())
+		assert.ErrorIs(t, stream.Err(), ErrDecode)
+		assert.Equal(t, "[DONE]", stream.Event().RawData)
+	})
+
+	t.Run("Failed subscribe due to non-2xx status", func(t *testing.T) {
+		transport := &sseTransport{connections: []sseConnection{{statusCode: http.StatusUnauthorized, body: "denied"}}}
+
+		caller := NewSSECaller[chatDelta](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Get(context.Background())
+		assert.Nil(t, stream)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+		assert.Equal(t, "denied", string(httpErr.Body))
+	})
+}
+
+type sseConnection struct {
+	statusCode int
+	body       string
+	err        error
+}
+
+// SSE transport that serves one scripted connection per request
+type sseTransport struct {
+	mu          sync.Mutex
+	connections []sseConnection
+	requests    []*http.Request
+	bodies      []string
+}
+
+func (s *sseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	connection := s.connections[len(s.requests)]
+	s.requests = append(s.requests, req)
+	s.bodies = append(s.bodies, string(body))
+
+	statusCode := connection.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	var reader io.Reader = strings.NewReader(connection.body)
+	if connection.err != nil {
+		reader = io.MultiReader(reader, &failReader{err: connection.err})
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(reader),
+		Request:    req,
+	}, nil
+}
+
+type failReader struct {
+	err error
+}
+
+func (f *failReader) Read(p []byte) (int, error) {
+	return 0, f.err
+}
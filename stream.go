@@ -0,0 +1,138 @@
+package httpcaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// maxErrorBodySize caps how much of an unsuccessful streamed response is kept
+// in HTTPError.Body.
+const maxErrorBodySize = 1 << 20
+
+// openStream sends a request whose response body is consumed by the caller
+// instead of being read here. body, when not nil, is resent on every attempt.
+// Retries, rate limiting and the circuit breaker
+// apply until response headers arrive; the per-attempt timeout does not, as
+// it would cut the stream short. Statuses accepted by acceptStatus are
+// returned as-is even when the success-status policy rejects them.
+func (c *Caller[request, response]) openStream(ctx context.Context, method string, requestURL string, headers http.Header, body []byte, acceptStatus func(statusCode int) bool) (*http.Response, error) {
+	maxAttempts := c.retryPolicy.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		if c.rateLimitGate != nil {
+			if err := c.rateLimitGate.wait(ctx, c.clock); err != nil {
+				return nil, fmt.Errorf("rate limit wait aborted: %w", err)
+			}
+		}
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter wait aborted: %w", err)
+			}
+		}
+
+		var serverResponse *http.Response
+		var err error
+		attemptStart := c.clock.Now()
+		if c.circuitBreaker == nil {
+			serverResponse, err = c.openAttempt(ctx, method, requestURL, headers, body, acceptStatus)
+		} else if err = c.circuitBreaker.allow(); err == nil {
+			serverResponse, err = c.openAttempt(ctx, method, requestURL, headers, body, acceptStatus)
+			c.circuitBreaker.record(err)
+		}
+		if c.logger != nil {
+			c.logStreamAttempt(ctx, method, attempt, c.clock.Now().Sub(attemptStart), headers, body, serverResponse, err)
+		}
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil || !c.retryPolicy.shouldRetry(err) {
+			return serverResponse, err
+		}
+		if c.onRetry != nil {
+			c.onRetry(attempt, err)
+		}
+
+		select {
+		case <-c.clock.After(c.retryPolicy.retryDelay(attempt, err, c.clock.Now())):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("retry aborted: %w: %w", ctx.Err(), err)
+		}
+	}
+}
+
+func (c *Caller[request, response]) openAttempt(ctx context.Context, method string, requestURL string, headers http.Header, body []byte, acceptStatus func(statusCode int) bool) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
+	}
+	for key, values := range headers {
+		httpReq.Header[key] = values
+	}
+	c.propagator.Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+	if c.onRequest != nil {
+		c.onRequest(httpReq)
+	}
+
+	serverResponse, err := c.handler(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w: %w", strings.ToLower(method), ErrTransport, err)
+	}
+
+	if c.rateLimitGate != nil {
+		c.rateLimitGate.observe(serverResponse.Header, c.clock.Now(), c.retryPolicy.maxRetryAfter())
+	}
+
+	if !c.isSuccessStatus(serverResponse.StatusCode) && (acceptStatus == nil || !acceptStatus(serverResponse.StatusCode)) {
+		defer serverResponse.Body.Close()
+		bytesResponse, err := io.ReadAll(io.LimitReader(serverResponse.Body, maxErrorBodySize))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReadBody, err)
+		}
+		if c.onResponse != nil {
+			c.onResponse(serverResponse, bytesResponse)
+		}
+		return nil, c.newHTTPError(method, requestURL, serverResponse, bytesResponse)
+	}
+
+	if c.onResponse != nil {
+		c.onResponse(serverResponse, nil)
+	}
+	return serverResponse, nil
+}
+
+// streamHeaders converts prepared headers for openStream.
+func streamHeaders(headers map[string]string) http.Header {
+	header := make(http.Header, len(headers))
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+	return header
+}
+
+// logStreamAttempt logs an attempt of openStream. The duration covers the
+// time to response headers and the body is not logged, as it is consumed
+// later.
+func (c *Caller[request, response]) logStreamAttempt(ctx context.Context, method string, attempt int, duration time.Duration, headers http.Header, body []byte, serverResponse *http.Response, err error) {
+	var reqBody *requestBody
+	if body != nil {
+		reqBody = &requestBody{contentType: headers.Get("Content-Type"), data: body}
+	}
+
+	var ex *exchange
+	var httpErr *HTTPError
+	if serverResponse != nil {
+		ex = &exchange{statusCode: serverResponse.StatusCode, header: serverResponse.Header, received: -1}
+	} else if errors.As(err, &httpErr) {
+		ex = &exchange{statusCode: httpErr.StatusCode, header: httpErr.Header, body: httpErr.Body, received: int64(len(httpErr.Body))}
+	}
+	c.logAttempt(ctx, method, attempt, duration, headers, reqBody, ex, err)
+}
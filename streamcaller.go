@@ -0,0 +1,127 @@
+package httpcaller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamCaller reads newline-delimited JSON (NDJSON, JSON Lines) responses
+// one item at a time as they arrive.
+type StreamCaller[item any] struct {
+	caller *Caller[struct{}, item]
+}
+
+func NewStreamCaller[item any](
+	httpClient *http.Client,
+	baseURL string,
+	endpoint string,
+	options ...CallerOptions,
+) *StreamCaller[item] {
+	return &StreamCaller[item]{
+		caller: NewCaller[struct{}, item](httpClient, baseURL, endpoint, options...),
+	}
+}
+
+// Stream sends a GET request and returns a cursor over the response lines.
+// The caller must Close the stream; cancelling ctx also ends it.
+func (h *StreamCaller[item]) Stream(ctx context.Context, optional ...CallOption) (*Stream[item], error) {
+	requestURL, headers, err := h.caller.prepare(nil, optional...)
+	if err != nil {
+		return nil, err
+	}
+
+	header := streamHeaders(headers)
+	if header.Get("Accept") == "" {
+		header.Set("Accept", "application/x-ndjson")
+	}
+
+	serverResponse, err := h.caller.openStream(ctx, http.MethodGet, requestURL, header, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stream[item]{
+		body:          serverResponse.Body,
+		reader:        bufio.NewReader(serverResponse.Body),
+		onDecodeError: h.caller.onDecodeError,
+	}, nil
+}
+
+// Stream is a cursor over a newline-delimited JSON response:
+//
+//	for stream.Next() {
+//		use(stream.Item())
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type Stream[item any] struct {
+	body          io.ReadCloser
+	reader        *bufio.Reader
+	onDecodeError func(raw []byte, err error)
+	item          item
+	err           error
+	done          bool
+}
+
+// Next decodes the next non-empty line. It returns false at the end of the
+// stream or on the first error, which is then reported by Err.
+func (s *Stream[item]) Next() bool {
+	if s.done {
+		return false
+	}
+
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			var next item
+			if decodeErr := json.Unmarshal(line, &next); decodeErr != nil {
+				s.fail(fmt.Errorf("%w: %w", ErrDecode, decodeErr))
+				if s.onDecodeError != nil {
+					s.onDecodeError(line, s.err)
+				}
+				return false
+			}
+			s.item = next
+			return true
+		}
+		if err == io.EOF {
+			s.fail(nil)
+			return false
+		}
+		if err != nil {
+			s.fail(fmt.Errorf("%w: %w", ErrReadBody, err))
+			return false
+		}
+	}
+}
+
+// Item returns the item decoded by the last call to Next.
+func (s *Stream[item]) Item() item {
+	return s.item
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *Stream[item]) Err() error {
+	return s.err
+}
+
+// Close releases the connection. It is safe to call more than once.
+func (s *Stream[item]) Close() error {
+	s.done = true
+	if s.body == nil {
+		return nil
+	}
+	body := s.body
+	s.body = nil
+	return body.Close()
+}
+
+func (s *Stream[item]) fail(err error) {
+	s.err = err
+	s.Close()
+}
@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package httpcaller
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iterator over the remaining items. Iteration stops at the
+// first error, which is yielded with a zero item. The stream is closed when
+// the loop ends, including on break.
+func (s *Stream[item]) All() iter.Seq2[item, error] {
+	return func(yield func(item, error) bool) {
+		defer s.Close()
+		for s.Next() {
+			if !yield(s.Item(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			var zero item
+			yield(zero, err)
+		}
+	}
+}
+
+// All sends a GET request and returns an iterator over the response items,
+// closing the connection when the loop ends.
+func (h *StreamCaller[item]) All(ctx context.Context, optional ...CallOption) iter.Seq2[item, error] {
+	return func(yield func(item, error) bool) {
+		stream, err := h.Stream(ctx, optional...)
+		if err != nil {
+			var zero item
+			yield(zero, err)
+			return
+		}
+		stream.All()(yield)
+	}
+}
@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamCallerAll(t *testing.T) {
+	t.Run("Successful iteration over all items", func(t *testing.T) {
+		transport := &streamTransport{body: "{\"id\":1}\n{\"id\":2}\n"}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		var ids []int
+		for event, err := range caller.All(context.Background()) {
+			assert.NoError(t, err)
+			ids = append(ids, event.ID)
+		}
+		assert.Equal(t, []int{1, 2}, ids)
+		assert.True(t, transport.closed)
+	})
+
+	t.Run("Successful early break closes the body", func(t *testing.T) {
+		transport := &streamTransport{body: "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Stream(context.Background())
+		assert.NoError(t, err)
+
+		for event, err := range stream.All() {
+			assert.NoError(t, err)
+			if event.ID == 1 {
+				break
+			}
+		}
+		assert.True(t, transport.closed)
+		assert.False(t, stream.Next())
+	})
+
+	t.Run("Failed iteration yields the request error", func(t *testing.T) {
+		transport := &streamTransport{statusCode: http.StatusBadGateway}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		var errs []error
+		for _, err := range caller.All(context.Background()) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(errs[0], &httpErr))
+		assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	})
+}
@@ -0,0 +1,170 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type streamEvent struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestStreamCaller(t *testing.T) {
+	t.Run("Successful stream of NDJSON items", func(t *testing.T) {
+		transport := &streamTransport{body: "{\"id\":1,\"name\":\"a\"}\n\n{\"id\":2,\"name\":\"b\"}\r\n{\"id\":3,\"name\":\"c\"}"}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Stream(context.Background())
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var items []streamEvent
+		for stream.Next() {
+			items = append(items, stream.Item())
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []streamEvent{{1, "a"}, {2, "b"}, {3, "c"}}, items)
+		assert.Equal(t, "application/x-ndjson", transport.request.Header.Get("Accept"))
+		assert.True(t, transport.closed)
+	})
+
+	t.Run("Successful stream keeps custom Accept header", func(t *testing.T) {
+		transport := &streamTransport{body: "{\"id\":1}\n"}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Stream(context.Background(), CallOption{
+			Header: map[string]string{"Accept": "application/jsonl"},
+		})
+		assert.NoError(t, err)
+		stream.Close()
+		assert.Equal(t, "application/jsonl", transport.request.Header.Get("Accept"))
+		assert.True(t, transport.closed)
+	})
+
+	t.Run("Failed stream due to malformed line", func(t *testing.T) {
+		transport := &streamTransport{body: "{\"id\":1}\n{invalid}\n{\"id\":3}\n"}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Stream(context.Background())
+		assert.NoError(t, err)
+
+		assert.True(t, stream.Next())
+		assert.Equal(t, 1, stream.Item().ID)
+		assert.False(t, stream.Next())
+		assert.ErrorIs(t, stream.Err(), ErrDecode)
+		assert.False(t, stream.Next())
+		assert.True(t, transport.closed)
+	})
+
+	t.Run("Failed stream due to non-2xx status", func(t *testing.T) {
+		transport := &streamTransport{body: `{"message": "denied"}`, statusCode: http.StatusForbidden}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		stream, err := caller.Stream(context.Background())
+		assert.Nil(t, stream)
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+		assert.JSONEq(t, `{"message": "denied"}`, string(httpErr.Body))
+		assert.True(t, transport.closed)
+	})
+
+	t.Run("Failed stream when context is cancelled", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		transport := &pipeTransport{body: pr}
+
+		caller := NewStreamCaller[streamEvent](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"events",
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := caller.Stream(ctx)
+		assert.NoError(t, err)
+
+		go func() {
+			pw.Write([]byte("{\"id\":1}\n"))
+			cancel()
+			pw.CloseWithError(context.Canceled)
+		}()
+
+		assert.True(t, stream.Next())
+		assert.False(t, stream.Next())
+		assert.ErrorIs(t, stream.Err(), context.Canceled)
+		assert.ErrorIs(t, stream.Err(), ErrReadBody)
+	})
+}
+
+// Stream transport that serves a fixed body and records whether it was closed
+type streamTransport struct {
+	body       string
+	statusCode int
+	request    *http.Request
+	closed     bool
+}
+
+func (s *streamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.request = req
+	statusCode := s.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/x-ndjson"}},
+		Body:       &trackingBody{Reader: strings.NewReader(s.body), closed: &s.closed},
+		Request:    req,
+	}, nil
+}
+
+type trackingBody struct {
+	io.Reader
+	closed *bool
+}
+
+func (b *trackingBody) Close() error {
+	*b.closed = true
+	return nil
+}
+
+// Pipe transport that streams whatever is written to the pipe
+type pipeTransport struct {
+	body io.ReadCloser
+}
+
+func (p *pipeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       p.body,
+		Request:    req,
+	}, nil
+}
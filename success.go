@@ -0,0 +1,74 @@
+package httpcaller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// matchBaseSuccessResponse checks that every key of expected is present at
+// the top level of body with a value that formats the same way.
+func matchBaseSuccessResponse(codec Codec, body []byte, expected map[string]interface{}) error {
+	var actual map[string]interface{}
+	var err error
+	if _, ok := codec.(jsonCodec); ok {
+		actual, err = scanJSONKeys(body, expected)
+	} else {
+		err = codec.Decode(bytes.NewReader(body), &actual)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: response map: %w", ErrDecode, err)
+	}
+
+	for key, expectedValue := range expected {
+		actualValue, exists := actual[key]
+		if !exists || fmt.Sprintf("%v", actualValue) != fmt.Sprintf("%v", expectedValue) {
+			return fmt.Errorf("%w for key %s: expected %v, got %v", ErrUnsuccessfulResponse, key, expectedValue, actualValue)
+		}
+	}
+	return nil
+}
+
+// scanJSONKeys walks the top-level object of body once and decodes only the
+// values of the wanted keys, skipping everything else without allocating.
+func scanJSONKeys(body []byte, wanted map[string]interface{}) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+
+	found := make(map[string]interface{}, len(wanted))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		if _, ok := wanted[key]; ok {
+			var value interface{}
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			found[key] = value
+			continue
+		}
+		if err := dec.Decode(&skipJSON{}); err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// skipJSON discards a JSON value. The decoder still validates it, but hands it
+// over as a slice of its own buffer, so nothing is copied.
+type skipJSON struct{}
+
+func (*skipJSON) UnmarshalJSON([]byte) error {
+	return nil
+}
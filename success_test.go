@@ -0,0 +1,177 @@
+package httpcaller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseSuccessResponse(t *testing.T) {
+	t.Run("Scans only the wanted top-level keys", func(t *testing.T) {
+		body := []byte(`{"items": [{"status": "nested"}], "status": "success", "code": 0, "meta": {"code": 1}}`)
+
+		found, err := scanJSONKeys(body, map[string]interface{}{"status": nil, "code": nil})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"status": "success", "code": float64(0)}, found)
+	})
+
+	t.Run("Rejects non-object and malformed bodies", func(t *testing.T) {
+		_, err := scanJSONKeys([]byte(`[1, 2]`), map[string]interface{}{"status": nil})
+		assert.Error(t, err)
+
+		_, err = scanJSONKeys([]byte(`{"status": }`), map[string]interface{}{"status": nil})
+		assert.Error(t, err)
+	})
+
+	t.Run("Matches values formatted the same way", func(t *testing.T) {
+		expected := map[string]interface{}{"userId": 1, "status": "success"}
+
+		err := matchBaseSuccessResponse(JSONCodec, []byte(`{"userId": 1, "status": "success"}`), expected)
+		assert.NoError(t, err)
+
+		err = matchBaseSuccessResponse(JSONCodec, []byte(`{"userId": 2, "status": "success"}`), expected)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Contains(t, err.Error(), "unsuccessful response for key userId: expected 1, got 2")
+
+		err = matchBaseSuccessResponse(JSONCodec, []byte(`{"status": "success"}`), expected)
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+	})
+
+	t.Run("JSON codec rejects data after the top-level value", func(t *testing.T) {
+		var res map[string]interface{}
+		assert.Error(t, JSONCodec.Decode(strings.NewReader(`{"test": "data"} {"test": "more"}`), &res))
+		assert.NoError(t, JSONCodec.Decode(strings.NewReader(`{"test": "data"}`+"\n"), &res))
+	})
+}
+
+func benchmarkBody(items int) string {
+	var sb strings.Builder
+	sb.WriteString(`{"status": "success", "items": [`)
+	for i := 0; i < items; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"id": %d, "name": "item-%d", "tags": ["a", "b", "c"], "price": %d.5}`, i, i, i)
+	}
+	sb.WriteString(`]}`)
+	return sb.String()
+}
+
+type benchmarkResponse struct {
+	Status string `json:"status"`
+	Items  []struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Price float64  `json:"price"`
+	} `json:"items"`
+}
+
+// legacyDecode is the decode path callers used before streaming: buffer the
+// whole body, then unmarshal it into the response and into a generic map.
+func legacyDecode(body io.Reader, baseSuccessResponse map[string]interface{}) (benchmarkResponse, error) {
+	var res benchmarkResponse
+	bytesResponse, err := io.ReadAll(body)
+	if err != nil {
+		return res, err
+	}
+	if err := json.Unmarshal(bytesResponse, &res); err != nil {
+		return res, err
+	}
+	if len(baseSuccessResponse) > 0 {
+		responseMap := make(map[string]interface{})
+		if err := json.Unmarshal(bytesResponse, &responseMap); err != nil {
+			return res, err
+		}
+		for key, expectedValue := range baseSuccessResponse {
+			actualValue, exists := responseMap[key]
+			if !exists || fmt.Sprintf("%v", actualValue) != fmt.Sprintf("%v", expectedValue) {
+				return res, fmt.Errorf("unsuccessful response for key %s", key)
+			}
+		}
+	}
+	return res, nil
+}
+
+func BenchmarkDecode(b *testing.B) {
+	body := benchmarkBody(1000)
+	successKeys := map[string]interface{}{"status": "success"}
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := legacyDecode(strings.NewReader(body), nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("legacy with success keys", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := legacyDecode(strings.NewReader(body), successKeys); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	benchmarkCaller := func(b *testing.B, options CallerOptions) {
+		mockClient := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			}),
+		}
+		caller := NewGetCaller[benchmarkResponse](mockClient, "https://example.com", "items", options)
+		ctx := context.Background()
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := caller.Get(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("streaming", func(b *testing.B) {
+		benchmarkCaller(b, CallerOptions{})
+	})
+
+	b.Run("streaming with success keys", func(b *testing.B) {
+		benchmarkCaller(b, CallerOptions{BaseSuccessResponse: successKeys})
+	})
+}
+
+func BenchmarkScanJSONKeys(b *testing.B) {
+	body := []byte(benchmarkBody(1000))
+	successKeys := map[string]interface{}{"status": "success"}
+
+	b.Run("generic map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			responseMap := make(map[string]interface{})
+			if err := json.Unmarshal(body, &responseMap); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("token scan", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := scanJSONKeys(body, successKeys); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
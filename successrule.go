@@ -0,0 +1,258 @@
+package httpcaller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SuccessRule checks one value of a response body. Build rules with Expect:
+//
+//	SuccessRules: []httpcaller.SuccessRule{
+//		httpcaller.Expect("code").In(0, 200),
+//		httpcaller.Expect("data.status").Equal("ok"),
+//		httpcaller.Expect("$.data.items[0].id").Matches(regexp.MustCompile(`^item-`)),
+//	}
+type SuccessRule struct {
+	path     string
+	segments []pathSegment
+	err      error
+	check    func(raw json.RawMessage) error
+}
+
+// Expectation selects the value a SuccessRule checks. Paths are dotted keys
+// with optional array indexes, such as "data.items[0].status", optionally
+// prefixed with "$" as in JSONPath. Keys containing dots can be quoted:
+// "$['a.b'].c".
+type Expectation struct {
+	path string
+}
+
+func Expect(path string) Expectation {
+	return Expectation{path: path}
+}
+
+// Equal requires the value to equal value as JSON, so "1" and 1 differ while
+// 1 and 1.0 do not.
+func (e Expectation) Equal(value any) SuccessRule {
+	return e.In(value)
+}
+
+// In requires the value to equal one of values as JSON.
+func (e Expectation) In(values ...any) SuccessRule {
+	expected := make([]any, len(values))
+	var err error
+	for i, value := range values {
+		if expected[i], err = normalizeJSON(value); err != nil {
+			break
+		}
+	}
+
+	rule := e.rule(func(raw json.RawMessage) error {
+		if raw == nil {
+			return fmt.Errorf("expected %s, got nothing", formatExpected(values))
+		}
+		var actual any
+		if err := json.Unmarshal(raw, &actual); err != nil {
+			return err
+		}
+		for _, value := range expected {
+			if reflect.DeepEqual(actual, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected %s, got %s", formatExpected(values), raw)
+	})
+	if rule.err == nil && err != nil {
+		rule.err = err
+	}
+	return rule
+}
+
+// Matches requires the value to be a string matched by re.
+func (e Expectation) Matches(re *regexp.Regexp) SuccessRule {
+	return e.rule(func(raw json.RawMessage) error {
+		var actual string
+		if raw == nil || json.Unmarshal(raw, &actual) != nil {
+			return fmt.Errorf("expected string matching %s, got %s", re, raw)
+		}
+		if !re.MatchString(actual) {
+			return fmt.Errorf("expected string matching %s, got %s", re, raw)
+		}
+		return nil
+	})
+}
+
+// Func passes the raw JSON value to fn, or nil when the path is missing. A
+// non-nil error fails the rule.
+func (e Expectation) Func(fn func(raw json.RawMessage) error) SuccessRule {
+	return e.rule(fn)
+}
+
+func (e Expectation) rule(check func(raw json.RawMessage) error) SuccessRule {
+	segments, err := parsePath(e.path)
+	return SuccessRule{path: e.path, segments: segments, err: err, check: check}
+}
+
+func normalizeJSON(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(data, &normalized)
+	return normalized, err
+}
+
+func formatExpected(values []any) string {
+	if len(values) == 1 {
+		data, _ := json.Marshal(values[0])
+		return string(data)
+	}
+	data, _ := json.Marshal(values)
+	return "one of " + string(data)
+}
+
+// matchSuccessRules checks rules against body. Bodies of other codecs are
+// decoded into a map and re-encoded as JSON first.
+func matchSuccessRules(codec Codec, body []byte, rules []SuccessRule) error {
+	body, err := jsonBody(codec, body)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if rule.err != nil {
+			return fmt.Errorf("success rule %q: %w", rule.path, rule.err)
+		}
+		raw, err := lookupJSON(body, rule.segments)
+		if err != nil {
+			return fmt.Errorf("%w: response map: %w", ErrDecode, err)
+		}
+		if err := rule.check(raw); err != nil {
+			return fmt.Errorf("%w for key %s: %w", ErrUnsuccessfulResponse, rule.path, err)
+		}
+	}
+	return nil
+}
+
+// jsonBody returns body as JSON, decoding it into a map and re-encoding it
+// when codec is not the JSON codec.
+func jsonBody(codec Codec, body []byte) ([]byte, error) {
+	if _, ok := codec.(jsonCodec); ok {
+		return body, nil
+	}
+	var decoded map[string]interface{}
+	if err := codec.Decode(bytes.NewReader(body), &decoded); err != nil {
+		return nil, fmt.Errorf("%w: response map: %w", ErrDecode, err)
+	}
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: response map: %w", ErrDecode, err)
+	}
+	return data, nil
+}
+
+// pathSegment is an object key, or an array index when index is not -1.
+type pathSegment struct {
+	key   string
+	index int
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	p := strings.TrimPrefix(path, "$")
+	if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	var segments []pathSegment
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '.':
+			j := i + 1
+			for j < len(p) && p[j] != '.' && p[j] != '[' {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("empty key at offset %d", i)
+			}
+			segments = append(segments, pathSegment{key: p[i+1 : j], index: -1})
+			i = j
+		case '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket at offset %d", i)
+			}
+			inner := p[i+1 : i+end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1], index: -1})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid array index %q", inner)
+				}
+				segments = append(segments, pathSegment{index: index})
+			}
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", p[i], i)
+		}
+	}
+	return segments, nil
+}
+
+// lookupJSON returns the raw value at path, or nil when it is missing. Like
+// scanJSONKeys, it skips over everything off the path without decoding it.
+func lookupJSON(body []byte, path []pathSegment) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for _, segment := range path {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		delim, _ := tok.(json.Delim)
+
+		switch {
+		case segment.index >= 0 && delim == '[':
+			for i := 0; i < segment.index; i++ {
+				if !dec.More() {
+					return nil, nil
+				}
+				if err := dec.Decode(&skipJSON{}); err != nil {
+					return nil, err
+				}
+			}
+		case segment.index < 0 && delim == '{':
+			for {
+				if !dec.More() {
+					return nil, nil
+				}
+				tok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				if key, _ := tok.(string); key == segment.key {
+					break
+				}
+				if err := dec.Decode(&skipJSON{}); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, nil
+		}
+		if !dec.More() {
+			return nil, nil
+		}
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
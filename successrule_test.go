@@ -0,0 +1,108 @@
+package httpcaller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessRules(t *testing.T) {
+	body := []byte(`{"code": 200, "id": "1", "data": {"status": "ok", "a.b": true, "items": [{"id": "item-1"}, {"id": "item-2"}]}}`)
+
+	t.Run("Successful rules on nested paths", func(t *testing.T) {
+		err := matchSuccessRules(JSONCodec, body, []SuccessRule{
+			Expect("code").In(0, 200),
+			Expect("data.status").Equal("ok"),
+			Expect("$.data.items[1].id").Equal("item-2"),
+			Expect("$.data['a.b']").Equal(true),
+			Expect("data.items[0].id").Matches(regexp.MustCompile(`^item-\d+$`)),
+			Expect("data.items").Func(func(raw json.RawMessage) error {
+				var items []json.RawMessage
+				if err := json.Unmarshal(raw, &items); err != nil {
+					return err
+				}
+				if len(items) != 2 {
+					return errors.New("expected two items")
+				}
+				return nil
+			}),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed rules use typed equality", func(t *testing.T) {
+		err := matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("id").Equal(1)})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Contains(t, err.Error(), `unsuccessful response for key id: expected 1, got "1"`)
+
+		err = matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("code").Equal(200.0)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed rules report missing and mismatched values", func(t *testing.T) {
+		err := matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("code").In(0, 201)})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Contains(t, err.Error(), "expected one of [0,201], got 200")
+
+		err = matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("data.items[5].id").Equal("x")})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Contains(t, err.Error(), "got nothing")
+
+		err = matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("code.value").Equal(1)})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		err = matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("code").Matches(regexp.MustCompile(`.`))})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		customErr := errors.New("custom")
+		err = matchSuccessRules(JSONCodec, body, []SuccessRule{Expect("missing").Func(func(raw json.RawMessage) error {
+			assert.Nil(t, raw)
+			return customErr
+		})})
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.ErrorIs(t, err, customErr)
+	})
+
+	t.Run("Failed rules with invalid paths", func(t *testing.T) {
+		for _, path := range []string{"data..status", "items[x]", "items[0", "items[0]status"} {
+			err := matchSuccessRules(JSONCodec, body, []SuccessRule{Expect(path).Equal(1)})
+			assert.Error(t, err, path)
+			assert.NotErrorIs(t, err, ErrUnsuccessfulResponse, path)
+		}
+	})
+
+	t.Run("Successful rules on form responses", func(t *testing.T) {
+		err := matchSuccessRules(FormCodec, []byte(`status=ok`), []SuccessRule{
+			Expect("status").Equal("ok"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed GET request due to success rules", func(t *testing.T) {
+		mockClient := &http.Client{
+			Transport: &mockTransport{
+				mockResponseBody: `{"test": "data", "result": {"code": "0"}}`,
+			},
+		}
+
+		caller := NewGetCaller[map[string]interface{}](
+			mockClient,
+			"https://example.com",
+			"test",
+			CallerOptions{
+				BaseSuccessResponse: map[string]interface{}{"test": "data"},
+				SuccessRules:        []SuccessRule{Expect("result.code").Equal(0)},
+			},
+		)
+
+		res, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+		assert.Contains(t, err.Error(), `unsuccessful response for key result.code: expected 0, got "0"`)
+		assert.Equal(t, "data", res["test"])
+	})
+}
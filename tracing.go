@@ -0,0 +1,106 @@
+package httpcaller
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/tanaphonble/httpcaller"
+
+// urlTemplate returns the path of the endpoint template, e.g. "/users/:id",
+// which names spans without the cardinality of expanded URLs.
+func urlTemplate(endpoint string) string {
+	if i := strings.IndexAny(endpoint, "?#"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	return "/" + strings.TrimLeft(endpoint, "/")
+}
+
+// startSpan starts the client span of a call. The span covers every attempt,
+// so it also accounts for retry delays.
+func (c *Caller[request, response]) startSpan(ctx context.Context, method string, requestURL string) (context.Context, trace.Span) {
+	template := urlTemplate(c.endpoint)
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLFull(requestURL),
+		semconv.URLTemplate(template),
+	}
+	if u, err := url.Parse(requestURL); err == nil {
+		attrs = append(attrs, semconv.ServerAddress(u.Hostname()))
+		if port := serverPort(u); port > 0 {
+			attrs = append(attrs, semconv.ServerPort(port))
+		}
+	}
+
+	return c.tracer.Start(ctx, method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan records the outcome of a call. Statuses of 400 and above mark the
+// span as failed, as the semantic conventions require for client spans.
+func endSpan(span trace.Span, statusCode int, attempts int, err error) {
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+	if attempts > 1 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(attempts - 1))
+	}
+
+	if err != nil {
+		span.SetAttributes(semconv.ErrorTypeKey.String(errorType(err)))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if statusCode >= 400 {
+		span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(statusCode)))
+		span.SetStatus(codes.Error, "")
+	}
+	span.End()
+}
+
+// errorType names the class of err for the error.type attribute.
+func errorType(err error) string {
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return strconv.Itoa(httpErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ErrTransport):
+		return "transport"
+	case errors.Is(err, ErrReadBody):
+		return "read_body"
+	case errors.Is(err, ErrDecode):
+		return "decode"
+	case errors.Is(err, ErrUnsuccessfulResponse):
+		return "unsuccessful_response"
+	}
+	return "_OTHER"
+}
+
+func serverPort(u *url.URL) int {
+	if port := u.Port(); port != "" {
+		p, _ := strconv.Atoi(port)
+		return p
+	}
+	switch u.Scheme {
+	case "http":
+		return 80
+	case "https":
+		return 443
+	}
+	return 0
+}
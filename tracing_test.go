@@ -0,0 +1,166 @@
+package httpcaller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	t.Run("Successful GET request records a client span", func(t *testing.T) {
+		provider, exporter := newTestTracerProvider()
+		transport := &recordingTransport{}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"users/:id",
+			CallerOptions{TracerProvider: provider},
+		)
+
+		_, err := caller.Get(context.Background(), CallOption{PathParam: map[string]string{"id": "123"}})
+		assert.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "GET /users/:id", span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		assert.Equal(t, codes.Unset, span.Status.Code)
+
+		attrs := spanAttributes(span)
+		assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+		assert.Equal(t, "https://example.com/users/123", attrs["url.full"].AsString())
+		assert.Equal(t, "/users/:id", attrs["url.template"].AsString())
+		assert.Equal(t, "example.com", attrs["server.address"].AsString())
+		assert.Equal(t, int64(443), attrs["server.port"].AsInt64())
+		assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+		assert.NotContains(t, attrs, attribute.Key("error.type"))
+
+		traceparent := transport.request.Header.Get("Traceparent")
+		assert.Equal(t, "00-"+span.SpanContext.TraceID().String()+"-"+span.SpanContext.SpanID().String()+"-01", traceparent)
+	})
+
+	t.Run("Successful POST request continues the parent trace and state", func(t *testing.T) {
+		provider, exporter := newTestTracerProvider()
+		transport := &recordingTransport{}
+
+		caller := NewPostCaller[map[string]interface{}, map[string]interface{}](
+			&http.Client{Transport: transport},
+			"http://localhost:8080",
+			"orders",
+			CallerOptions{TracerProvider: provider},
+		)
+
+		state, err := trace.ParseTraceState("vendor=value")
+		assert.NoError(t, err)
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		ctx = trace.ContextWithSpanContext(ctx, parent.SpanContext().WithTraceState(state))
+
+		_, err = caller.Post(ctx, map[string]interface{}{"test": "data"})
+		assert.NoError(t, err)
+		parent.End()
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 2)
+		span := spans[0]
+		assert.Equal(t, "POST /orders", span.Name)
+		assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Equal(t, int64(8080), spanAttributes(span)["server.port"].AsInt64())
+
+		assert.Contains(t, transport.request.Header.Get("Traceparent"), span.SpanContext.SpanID().String())
+		assert.Equal(t, "vendor=value", transport.request.Header.Get("Tracestate"))
+	})
+
+	t.Run("Failed request marks the span as an error", func(t *testing.T) {
+		provider, exporter := newTestTracerProvider()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &sequenceTransport{statusCodes: []int{503, 500}}},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				TracerProvider: provider,
+				RetryPolicy:    &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{503}},
+				Clock:          newFakeClock(),
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrUnsuccessfulResponse)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Equal(t, err.Error(), span.Status.Description)
+		assert.Len(t, span.Events, 1)
+		assert.Equal(t, "exception", span.Events[0].Name)
+
+		attrs := spanAttributes(span)
+		assert.Equal(t, int64(500), attrs["http.response.status_code"].AsInt64())
+		assert.Equal(t, "500", attrs["error.type"].AsString())
+		assert.Equal(t, int64(1), attrs["http.request.resend_count"].AsInt64())
+	})
+
+	t.Run("Failed request with transport error", func(t *testing.T) {
+		provider, exporter := newTestTracerProvider()
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: &mockTransport{networkError: true}},
+			"https://example.com",
+			"test",
+			CallerOptions{TracerProvider: provider},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.ErrorIs(t, err, ErrTransport)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		attrs := spanAttributes(spans[0])
+		assert.Equal(t, "transport", attrs["error.type"].AsString())
+		assert.NotContains(t, attrs, attribute.Key("http.response.status_code"))
+	})
+
+	t.Run("Successful request with custom propagator", func(t *testing.T) {
+		provider, _ := newTestTracerProvider()
+		transport := &recordingTransport{}
+
+		caller := NewGetCaller[map[string]interface{}](
+			&http.Client{Transport: transport},
+			"https://example.com",
+			"test",
+			CallerOptions{
+				TracerProvider: provider,
+				Propagator:     propagation.Baggage{},
+			},
+		)
+
+		_, err := caller.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, transport.request.Header.Get("Traceparent"))
+	})
+}